@@ -0,0 +1,165 @@
+package baccarat
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+func TestSettleBet(t *testing.T) {
+	cases := []struct {
+		betType string
+		winner  string
+		want    casino.Settlement
+	}{
+		{"Player", "Player", casino.Won(2500, 2500)},
+		{"Player", "Banker", casino.Lost(2500)},
+		{"Player", "Tie", casino.Pushed(2500)},
+		{"Banker", "Banker", casino.Won(2500, 2375)},
+		{"Banker", "Player", casino.Lost(2500)},
+		{"Banker", "Tie", casino.Pushed(2500)},
+		{"Tie", "Tie", casino.Won(2500, 20000)},
+		{"Tie", "Player", casino.Lost(2500)},
+	}
+	for _, c := range cases {
+		commission := casino.Commission{Rate: 0.05, Rule: casino.CommissionExact}
+		if got := SettleBet(c.betType, 2500, c.winner, &commission); got != c.want {
+			t.Errorf("%s bet, %s wins: got %+v, want %+v", c.betType, c.winner, got, c.want)
+		}
+	}
+}
+
+func TestPlayThirdCards(t *testing.T) {
+	// Player 2+3 draws an 8; Banker 10+3 stands on 3 against an 8
+	deck := &Deck{Cards: []Card{{2, "Hearts", "2"}, {3, "Clubs", "3"}, {10, "Spades", "K"}, {3, "Hearts", "3"}, {8, "Diamonds", "8"}, {1, "Clubs", "A"}}}
+	player, banker := DealInitialHands(deck)
+	draws := PlayThirdCards(deck, &player, &banker)
+	if !draws.PlayerDraws || draws.BankerDraws || draws.Natural {
+		t.Fatalf("draws = %+v, want only the player to draw", draws)
+	}
+	if draws.Banker != "Banker stands on 3 against a Player third card worth 8" {
+		t.Errorf("banker decision = %q", draws.Banker)
+	}
+	if got := DetermineWinner(player, banker); got != "Tie" {
+		t.Errorf("winner = %s, want a tie at 3", got)
+	}
+}
+
+func TestSettleSideBet(t *testing.T) {
+	ten, king, otherTen := Card{10, "Spades", "10"}, Card{10, "Hearts", "K"}, Card{10, "Clubs", "10"}
+	player := Hand{Cards: []Card{ten, king}}
+	banker := Hand{Cards: []Card{ten, otherTen}}
+	if got := SettleSideBet(PlayerPair, 1000, player, banker); got != casino.Lost(1000) {
+		t.Errorf("a ten and a King paid %+v, want a loss", got)
+	}
+	if got := SettleSideBet(BankerPair, 1000, player, banker); got != casino.Won(1000, 11000) {
+		t.Errorf("two tens paid %+v, want 11:1", got)
+	}
+
+	// Of the 416 cards of 8 decks, 31 of the 415 left pair the first: 1 - 12*31/415
+	if got := HouseEdge(PlayerPair, 8, 0); math.Abs(got-43.0/415) > 1e-12 {
+		t.Errorf("pair house edge = %.6f, want %.6f", got, 43.0/415)
+	}
+	if got := len(NewShoe(8).Cards); got != 416 {
+		t.Errorf("8 decks hold %d cards, want 416", got)
+	}
+}
+
+func TestTablePlay(t *testing.T) {
+	commission := casino.Commission{Rate: 0.05, Rule: casino.CommissionOwed, Unit: 100}
+	seat := Seat{Name: "Ann", InitialBet: casino.Dollars(25), InitialBalance: casino.Dollars(2000), ProfitGoal: casino.Dollars(500)}
+
+	// A seat alone at the table plays the session RunSimulation plays on the same shoe
+	resultChan := make(chan Result, 1)
+	historyChan := make(chan []GameHistory, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	RunSimulation("Ann", seat.InitialBet, seat.InitialBalance, seat.ProfitGoal, 0, 400, casino.Dollars(1000), 8, 11, commission, casino.Kelly{}, "", casino.Rebate{}, resultChan, historyChan, &wg)
+	alone := (&Table{Seats: []Seat{seat}, NumDecks: 8, TableLimit: casino.Dollars(1000), Commission: commission}).Play(400, 11)
+	if want := <-resultChan; alone.Seats[0].Result != want {
+		t.Errorf("seat alone = %+v, want %+v", alone.Seats[0].Result, want)
+	}
+
+	// Seats with the same strategy see the same hands and end the same way
+	kelly := seat
+	kelly.Name, kelly.Kelly, kelly.KellyBet = "Bo", casino.Kelly{Edge: 0.01, Variance: 1, Fraction: 0.5}, "Banker"
+	table := &Table{Seats: []Seat{seat, seat, kelly}, NumDecks: 8, TableLimit: casino.Dollars(1000), Commission: commission}
+	result := table.Play(400, 11)
+	if result.Seats[0].Result != alone.Seats[0].Result || result.Seats[1].Result != alone.Seats[0].Result {
+		t.Errorf("seats %+v and %+v, want both %+v", result.Seats[0], result.Seats[1], alone.Seats[0])
+	}
+
+	// The house wins what the seats lose
+	var lost, wagered casino.Money
+	for _, s := range result.Seats {
+		lost += seat.InitialBalance - s.Balance
+		wagered += s.Turnover
+	}
+	if result.HouseNet != lost || result.Wagered != wagered || result.Hands > 400 || result.HouseDrawdown < result.HouseWorstHand {
+		t.Errorf("table = %+v, seats lost %s over %s wagered", result, lost, wagered)
+	}
+}
+
+func TestRunSimulationRebate(t *testing.T) {
+	commission := casino.Commission{Rate: 0.05}
+	run := func(rebate casino.Rebate) Result {
+		resultChan := make(chan Result, 1)
+		historyChan := make(chan []GameHistory, 1)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		RunSimulation("Ann", casino.Dollars(25), casino.Dollars(500), casino.Dollars(5000), 0, 300, casino.Dollars(1000), 8, 7, commission, casino.Kelly{}, "", rebate, resultChan, historyChan, &wg)
+		return <-resultChan
+	}
+
+	// The program pays at the end of the session, so the hands played are the same
+	without := run(casino.Rebate{})
+	rebate := casino.Rebate{LossRate: 0.1, RollingRate: 0.005}
+	with := run(rebate)
+	if without.Rebate != 0 || with.Turnover != without.Turnover || with.Hands != without.Hands || with.Won != without.Won {
+		t.Fatalf("without = %+v, with = %+v", without, with)
+	}
+	if want := rebate.Pay(without.Balance-casino.Dollars(500), without.Turnover); with.Rebate != want || with.Balance != without.Balance+want {
+		t.Errorf("rebate %s, balance %s; want %s on %s", with.Rebate, with.Balance, want, without.Balance)
+	}
+}
+
+func TestOdds(t *testing.T) {
+	// The published chances and house edges of 8-deck punto banco
+	player, banker, tie := Odds(8)
+	if math.Abs(player-0.446247) > 1e-6 || math.Abs(banker-0.458597) > 1e-6 || math.Abs(tie-0.095156) > 1e-6 {
+		t.Fatalf("odds = %.6f %.6f %.6f", player, banker, tie)
+	}
+	for bet, want := range map[string]float64{"Player": 0.012351, "Banker": 0.010579, "Tie": 0.143596} {
+		if got := HouseEdge(bet, 8, 0.05); math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s edge = %.6f, want %.6f", bet, got, want)
+		}
+	}
+
+}
+
+func TestWonBeforeCollecting(t *testing.T) {
+	// With no stop loss or hand cap a session ends at its goal or broke.
+	// Commission owed counts as paid when deciding it reached the goal, so
+	// rounding the collection up to a $25 chip must not turn a win into a
+	// loss.
+	commission := casino.Commission{Rate: 0.05, Rule: casino.CommissionOwed, Unit: casino.Dollars(25)}
+	seat := Seat{Name: "Ann", InitialBet: casino.Dollars(10), InitialBalance: casino.Dollars(300), ProfitGoal: casino.Dollars(100)}
+	for seed := int64(1); seed <= 100; seed++ {
+		resultChan := make(chan Result, 1)
+		historyChan := make(chan []GameHistory, 1)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		RunSimulation("Ann", seat.InitialBet, seat.InitialBalance, seat.ProfitGoal, 0, 0, casino.Dollars(1000), 8, seed, commission, casino.Kelly{}, "", casino.Rebate{}, resultChan, historyChan, &wg)
+		if r := <-resultChan; r.Won != (r.Balance > 0) {
+			t.Errorf("seed %d: RunSimulation won %v with a balance of %s", seed, r.Won, r.Balance)
+		}
+		table := &Table{Seats: []Seat{seat, seat}, NumDecks: 8, TableLimit: casino.Dollars(1000), Commission: commission}
+		for _, s := range table.Play(0, seed).Seats {
+			if s.Won != (s.Balance > 0) {
+				t.Errorf("seed %d: Table.Play won %v with a balance of %s", seed, s.Won, s.Balance)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package baccarat deals punto banco from a multi-deck shoe and simulates
+// betting strategies on it.
+package baccarat
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Card struct represents a single playing card
+type Card struct {
+	Value int // Points the card counts in a hand: 1 for an Ace, 10 for a ten or a face card
+	Suit  string
+	Rank  string // A, 2 to 10, J, Q or K
+}
+
+// ranks are the thirteen cards of a suit and the points each one counts
+var ranks = []struct {
+	name  string
+	value int
+}{
+	{"A", 1}, {"2", 2}, {"3", 3}, {"4", 4}, {"5", 5}, {"6", 6}, {"7", 7},
+	{"8", 8}, {"9", 9}, {"10", 10}, {"J", 10}, {"Q", 10}, {"K", 10},
+}
+
+// Deck struct represents a deck of playing cards
+type Deck struct {
+	Cards []Card
+	Rand  *rand.Rand // Generator shuffling the shoe, nil to seed one from the clock
+}
+
+// Hand struct represents a hand of cards
+type Hand struct {
+	Cards []Card
+}
+
+// Initialize a new shoe of cards with multiple decks
+func NewShoe(numDecks int) *Deck {
+	suits := []string{"Hearts", "Diamonds", "Clubs", "Spades"}
+
+	deck := Deck{}
+
+	for i := 0; i < numDecks; i++ {
+		for _, suit := range suits {
+			for _, rank := range ranks {
+				deck.Cards = append(deck.Cards, Card{Value: rank.value, Suit: suit, Rank: rank.name})
+			}
+		}
+	}
+
+	return &deck
+}
+
+// Shuffle the deck of cards
+func (d *Deck) Shuffle() {
+	if d.Rand != nil {
+		d.Rand.Shuffle(len(d.Cards), func(i, j int) {
+			d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+		})
+		return
+	}
+	rand.Seed(time.Now().UnixNano())
+	rand.Shuffle(len(d.Cards), func(i, j int) {
+		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+	})
+}
+
+// Draw a card from the deck
+func (d *Deck) Draw() Card {
+	if len(d.Cards) == 0 {
+		panic("no cards left in the deck")
+	}
+	card := d.Cards[0]
+	d.Cards = d.Cards[1:]
+	return card
+}
+
+// Calculate the value of a hand in Baccarat
+func (h *Hand) Value() int {
+	total := 0
+	for _, card := range h.Cards {
+		if card.Value >= 10 {
+			total += 0
+		} else {
+			total += card.Value
+		}
+	}
+	return total % 10
+}
@@ -0,0 +1,103 @@
+package baccarat
+
+// Odds returns the exact chances that Player, Banker and Tie win the first
+// hand dealt from a full shoe, found by following every way the cards can
+// fall. Later hands of a shoe differ a little with the cards already dealt.
+func Odds(numDecks int) (float64, float64, float64) {
+	// counts[v] is the number of cards of value v left, 10 standing for the
+	// tens and face cards, sixteen of each deck
+	var counts [11]int
+	for v := 1; v <= 9; v++ {
+		counts[v] = 4 * numDecks
+	}
+	counts[10] = 16 * numDecks
+	left := 52 * numDecks
+
+	var player, banker, tie float64
+	var deal func(cards []int, chance float64)
+	deal = func(cards []int, chance float64) {
+		if winner, done := settleCards(cards); done {
+			switch winner {
+			case "Player":
+				player += chance
+			case "Banker":
+				banker += chance
+			default:
+				tie += chance
+			}
+			return
+		}
+		for v := 1; v <= 10; v++ {
+			if counts[v] == 0 {
+				continue
+			}
+			p := chance * float64(counts[v]) / float64(left)
+			counts[v]--
+			left--
+			deal(append(cards, v), p)
+			counts[v]++
+			left++
+		}
+	}
+	deal(make([]int, 0, 6), 1)
+	return player, banker, tie
+}
+
+// settleCards plays a hand from the values of the cards dealt so far, in
+// the order they leave the shoe, returning false when the rules call for
+// another card
+func settleCards(cards []int) (string, bool) {
+	if len(cards) < 4 {
+		return "", false
+	}
+	deck := &Deck{}
+	for _, v := range cards {
+		deck.Cards = append(deck.Cards, Card{Value: v})
+	}
+	playerHand, bankerHand := DealInitialHands(deck)
+	playerValue, bankerValue := playerHand.Value(), bankerHand.Value()
+	if playerValue < 8 && bankerValue < 8 {
+		playerThirdCardValue := -1
+		playerDraws := playerShouldDraw(playerValue)
+		if playerDraws {
+			if len(deck.Cards) == 0 {
+				return "", false
+			}
+			playerHand.Cards = append(playerHand.Cards, deck.Draw())
+			playerThirdCardValue = playerHand.Cards[2].Value
+		}
+		if bankerShouldDraw(bankerValue, playerHand.Value(), playerThirdCardValue, playerDraws) {
+			if len(deck.Cards) == 0 {
+				return "", false
+			}
+			bankerHand.Cards = append(bankerHand.Cards, deck.Draw())
+		}
+	}
+	return DetermineWinner(playerHand, bankerHand), true
+}
+
+// PairOdds returns the chance that the first two cards of a hand dealt
+// from a full shoe have the same rank
+func PairOdds(numDecks int) float64 {
+	return float64(4*numDecks-1) / float64(52*numDecks-1)
+}
+
+// HouseEdge returns the expected loss per unit staked on Player, Banker,
+// Tie or a pair bet for the first hand of a full shoe, with Banker wins
+// paying less the commission rate
+func HouseEdge(betType string, numDecks int, commissionRate float64) float64 {
+	if betType == PlayerPair || betType == BankerPair {
+		pair := PairOdds(numDecks)
+		return (1 - pair) - 11*pair
+	}
+	player, banker, tie := Odds(numDecks)
+	switch betType {
+	case "Player":
+		return banker - player
+	case "Banker":
+		return player - banker*(1-commissionRate)
+	case "Tie":
+		return (1 - tie) - 8*tie
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package baccarat
+
+import (
+	"fmt"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// Deal initial hands to the player and banker
+func DealInitialHands(deck *Deck) (Hand, Hand) {
+	playerHand := Hand{Cards: []Card{deck.Draw(), deck.Draw()}}
+	bankerHand := Hand{Cards: []Card{deck.Draw(), deck.Draw()}}
+	return playerHand, bankerHand
+}
+
+// Determine if the player should draw a third card
+func playerShouldDraw(handValue int) bool {
+	return handValue <= 5
+}
+
+// Determine if the banker should draw a third card
+func bankerShouldDraw(bankerValue, playerValue, playerThirdCardValue int, playerDraws bool) bool {
+	if !playerDraws {
+		return bankerValue <= 5
+	}
+
+	switch bankerValue {
+	case 0, 1, 2:
+		return true
+	case 3:
+		return playerThirdCardValue != 8
+	case 4:
+		return playerThirdCardValue >= 2 && playerThirdCardValue <= 7
+	case 5:
+		return playerThirdCardValue >= 4 && playerThirdCardValue <= 7
+	case 6:
+		return playerThirdCardValue == 6 || playerThirdCardValue == 7
+	default:
+		return false
+	}
+}
+
+// Draws records how the third cards were decided, to show at the table
+type Draws struct {
+	Natural     bool   // A two card 8 or 9 ended the hand
+	PlayerDraws bool   // The player drew a third card
+	BankerDraws bool   // The banker drew a third card
+	Player      string // Why the player drew or stood
+	Banker      string // Why the banker drew or stood
+}
+
+// Deal third card based on Baccarat rules
+func DealThirdCard(deck *Deck, playerHand, bankerHand *Hand) {
+	PlayThirdCards(deck, playerHand, bankerHand)
+}
+
+// PlayThirdCards deals the third cards the rules call for and explains each decision
+func PlayThirdCards(deck *Deck, playerHand, bankerHand *Hand) Draws {
+	playerValue := playerHand.Value()
+	bankerValue := bankerHand.Value()
+
+	// Natural win check
+	if playerValue == 8 || playerValue == 9 || bankerValue == 8 || bankerValue == 9 {
+		reason := fmt.Sprintf("Natural %d: nobody draws", larger(playerValue, bankerValue))
+		return Draws{Natural: true, Player: reason, Banker: reason}
+	}
+
+	var draws Draws
+	playerThirdCardValue := -1
+
+	if playerShouldDraw(playerValue) {
+		playerHand.Cards = append(playerHand.Cards, deck.Draw())
+		draws.PlayerDraws = true
+		draws.Player = fmt.Sprintf("Player draws on %d", playerValue)
+		playerThirdCardValue = playerHand.Cards[2].Value
+		playerValue = playerHand.Value()
+	} else {
+		draws.Player = fmt.Sprintf("Player stands on %d", playerValue)
+	}
+
+	draws.BankerDraws = bankerShouldDraw(bankerValue, playerValue, playerThirdCardValue, draws.PlayerDraws)
+	verb := "stands"
+	if draws.BankerDraws {
+		verb = "draws"
+		bankerHand.Cards = append(bankerHand.Cards, deck.Draw())
+	}
+	if draws.PlayerDraws {
+		draws.Banker = fmt.Sprintf("Banker %s on %d against a Player third card worth %d", verb, bankerValue, playerThirdCardValue%10)
+	} else {
+		draws.Banker = fmt.Sprintf("Banker %s on %d as the Player stood", verb, bankerValue)
+	}
+	return draws
+}
+
+// larger returns the larger of two hand values
+func larger(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Determine the winner
+func DetermineWinner(playerHand, bankerHand Hand) string {
+	playerValue := playerHand.Value()
+	bankerValue := bankerHand.Value()
+
+	if playerValue > bankerValue {
+		return "Player"
+	} else if bankerValue > playerValue {
+		return "Banker"
+	} else {
+		return "Tie"
+	}
+}
+
+// Settle a bet on the winner: Player pays 1:1, Banker 1:1 less commission
+// and Tie 8:1, while a tie pushes bets on Player or Banker
+func SettleBet(betType string, betValue casino.Money, winner string, commission *casino.Commission) casino.Settlement {
+	switch {
+	case betType == winner && betType == "Banker":
+		// Charge commission on Banker wins
+		return casino.Won(betValue, commission.Charge(betValue))
+	case betType == winner && betType == "Tie":
+		return casino.Won(betValue, betValue*8)
+	case betType == winner:
+		return casino.Won(betValue, betValue)
+	case winner == "Tie":
+		return casino.Pushed(betValue)
+	}
+	return casino.Lost(betValue)
+}
+
+// Side bets on the first two cards of a hand, each paying 11:1
+const (
+	PlayerPair = "Player Pair"
+	BankerPair = "Banker Pair"
+)
+
+// SettleSideBet settles a pair bet, which wins when the hand's first two
+// cards have the same rank, so a ten and a King are not a pair
+func SettleSideBet(betType string, betValue casino.Money, playerHand, bankerHand Hand) casino.Settlement {
+	hand := playerHand
+	if betType == BankerPair {
+		hand = bankerHand
+	}
+	if hand.Cards[0].Rank == hand.Cards[1].Rank {
+		return casino.Won(betValue, betValue*11)
+	}
+	return casino.Lost(betValue)
+}
@@ -0,0 +1,218 @@
+package baccarat
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// GameHistory struct represents a record of a single game
+type GameHistory struct {
+	PlayerName  string       `json:"player_name"`
+	BetValue    casino.Money `json:"bet_value"`
+	BetType     string       `json:"bet_type"`
+	PlayerValue int          `json:"player_value"`
+	BankerValue int          `json:"banker_value"`
+	Winner      string       `json:"winner"`
+	Outcome     string       `json:"outcome"`
+	Balance     casino.Money `json:"balance"`
+}
+
+// Result represents the outcome of a single simulation
+type Result struct {
+	Won         bool
+	Balance     casino.Money
+	Hands       int
+	Capped      bool         // The session reached the hand cap
+	MaxDrawdown float64      // Largest fall of the balance from its peak, as a share of the peak
+	Turnover    casino.Money // Total staked over the session
+	Rebate      casino.Money // Paid by the rebate program at the end, included in the balance
+}
+
+// Estimate the edge and variance per unit staked of a bet by dealing hands
+func EstimateOdds(betType string, numDecks int, hands int, commission casino.Commission) (float64, float64) {
+	commission.Rule = casino.CommissionExact
+	deck := NewShoe(numDecks)
+	deck.Shuffle()
+	mean, square := 0.0, 0.0
+	for i := 0; i < hands; i++ {
+		settlement, _, _ := PlayGame("", casino.Dollars(100), betType, 0, deck, numDecks, &commission)
+		x := float64(settlement.Net) / float64(settlement.Stake)
+		mean += x
+		square += x * x
+	}
+	mean /= float64(hands)
+	return mean, square/float64(hands) - mean*mean
+}
+
+// Load game history from JSON file
+func LoadGameHistory(filePath string) ([]GameHistory, error) {
+	var history []GameHistory
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return history, err
+	}
+	err = json.Unmarshal(data, &history)
+	return history, err
+}
+
+// Save game history to JSON file
+func SaveGameHistory(filePath string, history []GameHistory) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, data, 0644)
+}
+
+// Play a single game and return the result
+func PlayGame(playerName string, betValue casino.Money, betType string, balance casino.Money, deck *Deck, numDecks int, commission *casino.Commission) (casino.Settlement, casino.Money, GameHistory) {
+	// Start a new shoe if it's close to being empty, paying the commission owed on the old one
+	if len(deck.Cards) < 6 {
+		rng := deck.Rand
+		*deck = *NewShoe(numDecks)
+		deck.Rand = rng
+		deck.Shuffle()
+		balance -= commission.Collect()
+	}
+
+	// Deal initial hands
+	playerHand, bankerHand := DealInitialHands(deck)
+
+	// Deal third cards based on Baccarat rules
+	DealThirdCard(deck, &playerHand, &bankerHand)
+
+	// Determine the winner
+	winner := DetermineWinner(playerHand, bankerHand)
+
+	// Update balance based on the bet and the result
+	settlement := SettleBet(betType, betValue, winner, commission)
+	balance += settlement.Net
+
+	// Record the game result in history
+	game := GameHistory{
+		PlayerName:  playerName,
+		BetValue:    betValue,
+		BetType:     betType,
+		PlayerValue: playerHand.Value(),
+		BankerValue: bankerHand.Value(),
+		Winner:      winner,
+		Outcome:     settlement.Outcome.String(),
+		Balance:     balance,
+	}
+
+	return settlement, balance, game
+}
+
+// doubling is the betting strategy of RunSimulation: start on Player,
+// double the bet after each loss while switching between Player and
+// Banker, and go back to the first bet after a win or the sixth loss
+type doubling struct {
+	initialBet casino.Money
+	betValue   casino.Money
+	betType    string
+	step       int
+}
+
+// newDoubling starts the doubling strategy from its first bet
+func newDoubling(initialBet casino.Money) doubling {
+	return doubling{initialBet: initialBet, betValue: initialBet, betType: "Player", step: 1}
+}
+
+// settle moves the strategy on after a hand; a push leaves the bet as it was
+func (d *doubling) settle(outcome casino.Outcome) {
+	if outcome == casino.Win {
+		// Won the bet, reset to step 1
+		d.betValue = d.initialBet
+		d.betType = "Player"
+		d.step = 1
+	} else if outcome == casino.Lose {
+		// Lost the bet, follow the strategy
+		d.betValue *= 2
+		switch d.step {
+		case 1:
+			d.betType = "Banker"
+		case 2:
+			d.betType = "Player"
+		case 3:
+			d.betType = "Player"
+		case 4:
+			d.betType = "Banker"
+		case 5:
+			d.betType = "Banker"
+		case 6:
+			d.betValue = d.initialBet
+			d.betType = "Player"
+			d.step = 0
+		}
+		d.step++
+	}
+}
+
+// Run a single simulation, shuffling every shoe from the seed unless it is
+// 0. The rebate program pays out once the session is over, so it does not
+// decide whether the profit goal was reached.
+func RunSimulation(playerName string, initialBet casino.Money, initialBalance casino.Money, profitGoal casino.Money, stopLoss casino.Money, maxHands int, tableLimit casino.Money, numDecks int, seed int64, commission casino.Commission, kelly casino.Kelly, kellyBet string, rebate casino.Rebate, resultChan chan<- Result, historyChan chan<- []GameHistory, wg *sync.WaitGroup) {
+	defer wg.Done()
+	// Initialize variables
+	balance := initialBalance
+	strategy := newDoubling(initialBet)
+	hands := 0
+	var turnover casino.Money
+	drawdown := casino.Drawdown{Peak: initialBalance}
+	var gameHistories []GameHistory
+
+	// Create and shuffle the initial shoe
+	deck := NewShoe(numDecks)
+	if seed != 0 {
+		deck.Rand = casino.NewRand(seed)
+	}
+	deck.Shuffle()
+
+	// Play the game until we reach the profit goal, fall below the stop
+	// loss or hit the hand cap, counting the commission owed as already paid
+	for balance > 0 && balance-commission.Owed < initialBalance+profitGoal && (stopLoss == 0 || balance-commission.Owed >= stopLoss) && (maxHands == 0 || hands < maxHands) {
+		// Kelly sizing stakes a share of what the player would have after paying the commission owed
+		if kelly.Fraction > 0 {
+			strategy.betType = kellyBet
+			if strategy.betValue = kelly.Stake(balance - commission.Owed); strategy.betValue == 0 {
+				break
+			}
+		}
+
+		// Check if betValue exceeds table limit
+		if strategy.betValue > tableLimit {
+			strategy.betValue = tableLimit
+		}
+
+		settlement, newBalance, gameHistory := PlayGame(playerName, strategy.betValue, strategy.betType, balance, deck, numDecks, &commission)
+		gameHistories = append(gameHistories, gameHistory)
+		strategy.settle(settlement.Outcome)
+		balance = newBalance
+		turnover += settlement.Stake
+		hands++
+		drawdown.Record(balance - commission.Owed)
+	}
+
+	// Judge the goal as the loop did, with the commission owed counted as
+	// paid, before paying it rounded up to the chip
+	won := balance-commission.Owed >= initialBalance+profitGoal
+	balance -= commission.Collect()
+
+	// The rebate program settles up with the player on the way out
+	paid := rebate.Pay(balance-initialBalance, turnover)
+	balance += paid
+
+	resultChan <- Result{
+		Won:         won,
+		Balance:     balance,
+		Hands:       hands,
+		Capped:      maxHands > 0 && hands >= maxHands,
+		MaxDrawdown: drawdown.Max,
+		Turnover:    turnover,
+		Rebate:      paid,
+	}
+	historyChan <- gameHistories
+}
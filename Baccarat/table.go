@@ -0,0 +1,201 @@
+package baccarat
+
+import (
+	casino "github.com/BryceWayne/casino"
+)
+
+// MaxSeats is the number of players a baccarat table seats
+const MaxSeats = 14
+
+// Seat is a player at a shared table, with their own bankroll, goals and
+// betting strategy
+type Seat struct {
+	Name           string
+	InitialBet     casino.Money
+	InitialBalance casino.Money
+	ProfitGoal     casino.Money
+	StopLoss       casino.Money
+	Kelly          casino.Kelly // Sizes the bets instead of the doubling strategy when its Fraction is positive
+	KellyBet       string
+	Rebate         casino.Rebate // Paid to the seat when it leaves
+}
+
+// SeatResult is how a seat's session at a shared table ended
+type SeatResult struct {
+	Name string
+	Result
+}
+
+// TableResult is how a shared shoe went for every seat and for the house
+type TableResult struct {
+	Seats          []SeatResult
+	Hands          int          // Hands dealt while any seat was playing
+	Wagered        casino.Money // Total staked by every seat
+	HouseNet       casino.Money // What the house won from every seat, commission included and rebates paid
+	HouseWorstHand casino.Money // Most the house lost on a single hand
+	HouseDrawdown  casino.Money // Largest fall of the house's running net from its peak
+}
+
+// Table is a baccarat table where every seat bets on the same hands,
+// dealt from one shoe. The cards do not depend on the bets, so the seats
+// win and lose together, and the house carries all their bets at once.
+type Table struct {
+	Seats      []Seat
+	NumDecks   int
+	TableLimit casino.Money
+	Commission casino.Commission // How each seat pays commission; owed commission is kept seat by seat
+}
+
+// seated is a seat during play
+type seated struct {
+	seat       Seat
+	balance    casino.Money
+	strategy   doubling
+	commission casino.Commission
+	hands      int
+	wagered    casino.Money
+	drawdown   casino.Drawdown
+	left       bool
+	won        bool // Reached the profit goal when it left
+}
+
+// playing reports whether a seat stays for the next hand: it leaves at
+// its profit goal, below its stop loss or when broke, counting the
+// commission it owes as already paid
+func (s *seated) playing() bool {
+	available := s.balance - s.commission.Owed
+	return !s.left && s.balance > 0 && available < s.seat.InitialBalance+s.seat.ProfitGoal && (s.seat.StopLoss == 0 || available >= s.seat.StopLoss)
+}
+
+// leave takes a seat away from the table, paying the commission it owes,
+// and returns what was paid. Whether it reached its goal is judged as
+// playing judges it, before the commission is rounded up to the chip.
+func (s *seated) leave() casino.Money {
+	s.left = true
+	s.won = s.balance-s.commission.Owed >= s.seat.InitialBalance+s.seat.ProfitGoal
+	owed := s.commission.Collect()
+	s.balance -= owed
+	return owed
+}
+
+// Play deals hands from one shoe, shuffled from the seed unless it is 0,
+// until every seat has left or maxHands are dealt (0 for no cap). Each
+// hand settles the bet of every seat still playing.
+func (t *Table) Play(maxHands int, seed int64) TableResult {
+	seats := make([]*seated, len(t.Seats))
+	for i, seat := range t.Seats {
+		seats[i] = &seated{
+			seat:       seat,
+			balance:    seat.InitialBalance,
+			strategy:   newDoubling(seat.InitialBet),
+			commission: t.Commission,
+			drawdown:   casino.Drawdown{Peak: seat.InitialBalance},
+		}
+	}
+	deck := NewShoe(t.NumDecks)
+	if seed != 0 {
+		deck.Rand = casino.NewRand(seed)
+	}
+	deck.Shuffle()
+
+	var result TableResult
+	var housePeak casino.Money
+	for maxHands == 0 || result.Hands < maxHands {
+		// Take every seat's bet, letting go of those that are done
+		var hand casino.Money
+		betting := 0
+		for _, s := range seats {
+			if s.left {
+				continue
+			}
+			if !s.playing() {
+				hand += s.leave()
+				continue
+			}
+			if s.seat.Kelly.Fraction > 0 {
+				s.strategy.betType = s.seat.KellyBet
+				if s.strategy.betValue = s.seat.Kelly.Stake(s.balance - s.commission.Owed); s.strategy.betValue == 0 {
+					hand += s.leave()
+					continue
+				}
+			}
+			if s.strategy.betValue > t.TableLimit {
+				s.strategy.betValue = t.TableLimit
+			}
+			betting++
+		}
+		if betting == 0 {
+			result.HouseNet += hand
+			break
+		}
+
+		// Start a new shoe if it's close to being empty, every seat paying the commission owed on the old one
+		if len(deck.Cards) < 6 {
+			rng := deck.Rand
+			*deck = *NewShoe(t.NumDecks)
+			deck.Rand = rng
+			deck.Shuffle()
+			for _, s := range seats {
+				owed := s.commission.Collect()
+				s.balance -= owed
+				hand += owed
+			}
+		}
+
+		// Deal one hand for the whole table and settle every bet on it
+		playerHand, bankerHand := DealInitialHands(deck)
+		DealThirdCard(deck, &playerHand, &bankerHand)
+		winner := DetermineWinner(playerHand, bankerHand)
+		for _, s := range seats {
+			if s.left {
+				continue
+			}
+			settlement := SettleBet(s.strategy.betType, s.strategy.betValue, winner, &s.commission)
+			s.balance += settlement.Net
+			s.wagered += settlement.Stake
+			s.hands++
+			s.strategy.settle(settlement.Outcome)
+			s.drawdown.Record(s.balance - s.commission.Owed)
+			hand -= settlement.Net
+		}
+		result.Hands++
+
+		// Follow the house's running net
+		result.HouseNet += hand
+		if hand < -result.HouseWorstHand {
+			result.HouseWorstHand = -hand
+		}
+		if result.HouseNet > housePeak {
+			housePeak = result.HouseNet
+		}
+		if housePeak-result.HouseNet > result.HouseDrawdown {
+			result.HouseDrawdown = housePeak - result.HouseNet
+		}
+	}
+
+	// The seats still playing pay the commission owed before leaving the
+	// table, then the house pays each seat its rebate
+	for _, s := range seats {
+		capped := !s.left && maxHands > 0 && result.Hands >= maxHands
+		if !s.left {
+			result.HouseNet += s.leave()
+		}
+		paid := s.seat.Rebate.Pay(s.balance-s.seat.InitialBalance, s.wagered)
+		s.balance += paid
+		result.HouseNet -= paid
+		result.Wagered += s.wagered
+		result.Seats = append(result.Seats, SeatResult{
+			Name: s.seat.Name,
+			Result: Result{
+				Won:         s.won,
+				Balance:     s.balance,
+				Hands:       s.hands,
+				Capped:      capped,
+				MaxDrawdown: s.drawdown.Max,
+				Turnover:    s.wagered,
+				Rebate:      paid,
+			},
+		})
+	}
+	return result
+}
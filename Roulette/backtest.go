@@ -0,0 +1,73 @@
+package roulette
+
+import "os"
+
+// NoSpin is returned by a spin source that has run out of spins
+const NoSpin = -1
+
+// SpinSource produces the winning pocket of each spin
+type SpinSource interface {
+	// Spin returns the next winning pocket, or NoSpin when there are no more
+	Spin() int
+}
+
+// SpinLog replays recorded spins in order
+type SpinLog struct {
+	spins []int
+	next  int
+}
+
+// NewSpinLog replays spins; a positive maxSpins stops the log after that many
+func NewSpinLog(spins []int, maxSpins int) *SpinLog {
+	if maxSpins > 0 && maxSpins < len(spins) {
+		spins = spins[:maxSpins]
+	}
+	return &SpinLog{spins: spins}
+}
+
+// Spin returns the next recorded pocket
+func (l *SpinLog) Spin() int {
+	if l.next >= len(l.spins) {
+		return NoSpin
+	}
+	pocket := l.spins[l.next]
+	l.next++
+	return pocket
+}
+
+// Used returns how many spins have been replayed
+func (l *SpinLog) Used() int {
+	return l.next
+}
+
+// LoadSpinFile reads a spin log file with one pocket per line
+func LoadSpinFile(path string, european bool) ([]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadSpins(file, european)
+}
+
+// WalkForward splits a spin log into back-to-back independent sessions.
+// play runs one session against the source it is given, and the next
+// session starts with the first spin the previous one did not use. A cap
+// on the spins of a session belongs to play, so that a session stopped by
+// it is not mistaken for one the log ran out on. It returns the number of
+// sessions played.
+func WalkForward(spins []int, play func(source SpinSource)) int {
+	sessions := 0
+	for start := 0; start < len(spins); {
+		log := NewSpinLog(spins[start:], 0)
+		play(log)
+		sessions++
+
+		// A session that cannot place a bet would never move forward
+		if log.Used() == 0 {
+			break
+		}
+		start += log.Used()
+	}
+	return sessions
+}
@@ -0,0 +1,117 @@
+package roulette
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+func TestReadSpins(t *testing.T) {
+	spins, err := ReadSpins(strings.NewReader("# table 3\n17\n\n00\n 0 \n36\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{17, DoubleZero, 0, 36}; len(spins) != len(want) || spins[0] != want[0] || spins[1] != want[1] || spins[2] != want[2] || spins[3] != want[3] {
+		t.Errorf("spins = %v, want %v", spins, want)
+	}
+	for _, log := range []string{"17\n00\n", "37\n", "red\n", "-1\n"} {
+		if _, err := ReadSpins(strings.NewReader(log), true); err == nil {
+			t.Errorf("ReadSpins(%q) should fail on a European wheel", log)
+		}
+	}
+
+	// The log the README backtests
+	spins, err = LoadSpinFile("testdata/american.txt", false)
+	if err != nil || len(spins) != 3000 {
+		t.Errorf("testdata/american.txt: %d spins, %v", len(spins), err)
+	}
+	if _, err := LoadSpinFile("testdata/missing.txt", false); !os.IsNotExist(err) {
+		t.Errorf("missing log: %v", err)
+	}
+}
+
+func TestSpinLog(t *testing.T) {
+	log := NewSpinLog([]int{5, 6, 7}, 2)
+	if a, b, c := log.Spin(), log.Spin(), log.Spin(); a != 5 || b != 6 || c != NoSpin {
+		t.Errorf("spins %d %d %d, want 5 6 and no spin past the cap", a, b, c)
+	}
+	if log.Used() != 2 {
+		t.Errorf("used %d spins, want 2", log.Used())
+	}
+}
+
+func TestWalkForward(t *testing.T) {
+	// Each session takes two spins, so seven spins make four sessions
+	var lengths []int
+	sessions := WalkForward([]int{1, 2, 3, 4, 5, 6, 7}, func(source SpinSource) {
+		n := 0
+		for n < 2 && source.Spin() != NoSpin {
+			n++
+		}
+		lengths = append(lengths, n)
+	})
+	if sessions != 4 || len(lengths) != 4 || lengths[3] != 1 {
+		t.Errorf("%d sessions of %v spins, want 4 ending with a single spin", sessions, lengths)
+	}
+
+	// A session that takes no spin ends the walk instead of looping forever
+	if sessions := WalkForward([]int{1, 2}, func(SpinSource) {}); sessions != 1 {
+		t.Errorf("%d sessions without spins, want 1", sessions)
+	}
+}
+
+func TestWalkForwardSessionCap(t *testing.T) {
+	// Flat $1 on red from $100 never reaches a $1000 goal, so only the cap
+	// or the end of the log stops a session
+	spins, err := LoadSpinFile("testdata/american.txt", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bets, _ := ParseBets("red:1", Classic{})
+	progressions := []ProgressionConfig{{System: Flat, Unit: casino.Dollars(1)}}
+	var results []Result
+	WalkForward(spins, func(source SpinSource) {
+		result, err := RunSimulation(Classic{}, source, casino.Dollars(100), bets, progressions, TableLimits{}, LimitCap, casino.Dollars(1000), 0, 700)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	})
+	if len(results) != 5 {
+		t.Fatalf("%d sessions, want 5 of up to 700 spins from 3000", len(results))
+	}
+	for i, r := range results[:4] {
+		if !r.Capped || r.Exhausted || r.SpinCount != 700 {
+			t.Errorf("session %d = %+v, want stopped at the cap", i, r)
+		}
+	}
+	if last := results[4]; last.Capped || !last.Exhausted || last.SpinCount != 200 {
+		t.Errorf("last session = %+v, want cut short by the end of the log", last)
+	}
+}
+
+func TestRunSimulationSpinsTheVariant(t *testing.T) {
+	// Double ball draws two pockets from the log for every spin
+	spins := []int{1, 3, 2, 4, 5, 7, 9, 12, 14, 16}
+	v := DoubleBall{Classic{European: true}}
+	bets, _ := ParseBets("red:1", v)
+	progressions := []ProgressionConfig{{System: Flat, Unit: casino.Dollars(1)}}
+	result, err := RunSimulation(v, NewSpinLog(spins, 0), casino.Dollars(100), bets, progressions, TableLimits{}, LimitCap, casino.Dollars(1000), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := casino.Dollars(100)
+	for i := 0; i < len(spins); i += 2 {
+		want += v.Settle(bets[0], Round{Balls: spins[i : i+2]}).Net
+	}
+	if result.SpinCount != 5 || !result.Exhausted || result.Balance != want {
+		t.Errorf("result = %+v, want 5 spins ending on %s", result, want)
+	}
+
+	progressions[0].System = "nope"
+	if _, err := RunSimulation(v, NewSpinLog(spins, 0), casino.Dollars(100), bets, progressions, TableLimits{}, LimitCap, casino.Dollars(1000), 0, 0); err == nil {
+		t.Error("an unknown progression should fail")
+	}
+}
@@ -0,0 +1,297 @@
+package roulette
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// Kind names a bet on the roulette layout
+type Kind string
+
+const (
+	Straight Kind = "straight"
+	Split    Kind = "split"
+	Street   Kind = "street" // Three numbers across a row, or a trio with zero
+	Corner   Kind = "corner" // Four numbers meeting at a corner, or 0-1-2-3
+	Basket   Kind = "basket" // 0-00-1-2-3 on an American layout
+	SixLine  Kind = "sixline"
+	Dozen    Kind = "dozen"
+	Column   Kind = "column"
+	Red      Kind = "red"
+	Black    Kind = "black"
+	Odd      Kind = "odd"
+	Even     Kind = "even"
+	Low      Kind = "low"
+	High     Kind = "high"
+)
+
+// redNumbers are the red pockets of the classic layout
+var redNumbers = map[int]bool{1: true, 3: true, 5: true, 7: true, 9: true, 12: true, 14: true, 16: true, 18: true, 19: true, 21: true, 23: true, 25: true, 27: true, 30: true, 32: true, 34: true, 36: true}
+
+// Bet is a wager on a set of numbers of the layout
+type Bet struct {
+	Kind    Kind
+	Numbers []int
+	Amount  casino.Money
+}
+
+// IsRed reports whether a pocket is red; zeros are neither red nor black
+func IsRed(pocket int) bool {
+	return redNumbers[pocket]
+}
+
+// Color returns the color of a pocket: red, black or green for the zeros
+func Color(pocket int) string {
+	switch {
+	case pocket == 0 || pocket == DoubleZero:
+		return "green"
+	case redNumbers[pocket]:
+		return "red"
+	}
+	return "black"
+}
+
+// Class returns whether the bet is an inside or outside bet
+func (b Bet) Class() BetClass {
+	switch b.Kind {
+	case Straight, Split, Street, Corner, Basket, SixLine:
+		return Inside
+	}
+	return Outside
+}
+
+// Covers reports whether the bet wins when the ball lands in the pocket
+func (b Bet) Covers(pocket int) bool {
+	for _, n := range b.Numbers {
+		if n == pocket {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the bet, for example "split 17-20"
+func (b Bet) String() string {
+	if b.Class() == Outside && b.Kind != Dozen && b.Kind != Column {
+		return string(b.Kind)
+	}
+	s := string(b.Kind) + " "
+	if b.Kind == Dozen || b.Kind == Column {
+		return fmt.Sprintf("%s%d", s, b.index())
+	}
+	for i, n := range b.Numbers {
+		if i > 0 {
+			s += "-"
+		}
+		s += PocketName(n)
+	}
+	return s
+}
+
+// ParseBet reads a bet written like its String, such as "split 17-20",
+// "dozen 3" or "red", checking it against the variant's layout
+func ParseBet(s string, v Variant) (Bet, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return Bet{}, fmt.Errorf("empty bet")
+	}
+	bet := Bet{Kind: Kind(fields[0])}
+	switch bet.Kind {
+	case Red, Black, Odd, Even, Low, High:
+		if len(fields) != 1 {
+			return Bet{}, fmt.Errorf("invalid bet %q", s)
+		}
+		numbers, err := OutsideNumbers(bet.Kind, 0, v.Top())
+		bet.Numbers = numbers
+		return bet, err
+	case Dozen, Column:
+		if len(fields) != 2 {
+			return Bet{}, fmt.Errorf("%s needs which one, like %q", bet.Kind, bet.Kind+" 1")
+		}
+		which, err := strconv.Atoi(fields[1])
+		if err != nil || which < 1 || which > 3 {
+			return Bet{}, fmt.Errorf("invalid bet %q", s)
+		}
+		numbers, err := OutsideNumbers(bet.Kind, which, v.Top())
+		bet.Numbers = numbers
+		return bet, err
+	}
+
+	if len(fields) != 2 {
+		return Bet{}, fmt.Errorf("invalid bet %q", s)
+	}
+	for _, field := range strings.Split(fields[1], "-") {
+		pocket, err := ParsePocket(field, !v.American())
+		if err != nil {
+			return Bet{}, err
+		}
+		bet.Numbers = append(bet.Numbers, pocket)
+	}
+	if err := ValidInside(bet.Kind, bet.Numbers, v.Top(), v.American()); err != nil {
+		return Bet{}, err
+	}
+	return bet, nil
+}
+
+// ParseBets reads a comma separated layout of bets, each with an optional
+// amount after a colon, such as "high:100,street 7-8-9:25"
+func ParseBets(s string, v Variant) ([]Bet, error) {
+	var bets []Bet
+	for _, field := range strings.Split(s, ",") {
+		text, amount, found := strings.Cut(field, ":")
+		bet, err := ParseBet(text, v)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			if bet.Amount, err = casino.ParseMoney(amount); err != nil {
+				return nil, err
+			}
+			if bet.Amount <= 0 {
+				return nil, fmt.Errorf("bet %s needs a positive amount", bet)
+			}
+		}
+		bets = append(bets, bet)
+	}
+	return bets, nil
+}
+
+// index returns which dozen or column an outside bet covers
+func (b Bet) index() int {
+	if b.Kind == Dozen {
+		return (b.Numbers[0]-1)/12 + 1
+	}
+	return (b.Numbers[0]-1)%3 + 1
+}
+
+// OutsideNumbers returns the numbers covered by an outside bet on a layout
+// numbered 1 to top; dozens and columns take which one (1 to 3)
+func OutsideNumbers(kind Kind, which, top int) ([]int, error) {
+	var numbers []int
+	for n := 1; n <= top; n++ {
+		var covered bool
+		switch kind {
+		case Dozen:
+			covered = (n-1)/12+1 == which
+		case Column:
+			covered = (n-1)%3+1 == which
+		case Red:
+			covered = redNumbers[n]
+		case Black:
+			covered = !redNumbers[n]
+		case Odd:
+			covered = n%2 == 1
+		case Even:
+			covered = n%2 == 0
+		case Low:
+			covered = n <= top/2
+		case High:
+			covered = n > top/2
+		default:
+			return nil, fmt.Errorf("%s is not an outside bet", kind)
+		}
+		if covered {
+			numbers = append(numbers, n)
+		}
+	}
+	if len(numbers) == 0 {
+		return nil, fmt.Errorf("%s %d is not on the layout", kind, which)
+	}
+	return numbers, nil
+}
+
+// sameStreet reports whether two numbers share a row of the layout
+func sameStreet(a, b int) bool {
+	return (a-1)/3 == (b-1)/3
+}
+
+// ValidInside checks that the numbers of an inside bet sit together on a
+// layout numbered 1 to top; american allows bets with the double zero
+func ValidInside(kind Kind, numbers []int, top int, american bool) error {
+	sorted := append([]int(nil), numbers...)
+	sort.Ints(sorted)
+	for _, n := range sorted {
+		if n > top && !(american && n == DoubleZero) || n < 0 {
+			return fmt.Errorf("%s is not on the layout", PocketName(n))
+		}
+	}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return fmt.Errorf("%s is covered twice", PocketName(sorted[i]))
+		}
+	}
+
+	zeros := map[int]bool{0: true}
+	if american {
+		zeros[DoubleZero] = true
+	}
+	key := fmt.Sprint(sorted)
+	invalid := fmt.Errorf("%v is not a valid %s", sorted, kind)
+	switch kind {
+	case Straight:
+		if len(sorted) != 1 {
+			return invalid
+		}
+	case Split:
+		if len(sorted) != 2 {
+			return invalid
+		}
+		a, b := sorted[0], sorted[1]
+		switch {
+		case zeros[a] && zeros[b]:
+		case a == 0 && !american && b <= 3:
+		case a == 0 && american && (b == 1 || b == 2):
+		case a != 0 && b == DoubleZero && (a == 2 || a == 3):
+		case a > 0 && b <= top && (b-a == 3 || b-a == 1 && sameStreet(a, b)):
+		default:
+			return invalid
+		}
+	case Street:
+		if len(sorted) != 3 {
+			return invalid
+		}
+		// Trios with the zeros
+		switch {
+		case key == "[0 1 2]", key == "[0 2 3]" && !american, key == "[2 3 37]" && american:
+			return nil
+		}
+		if sorted[0] == 0 || sorted[0]%3 != 1 || sorted[1] != sorted[0]+1 || sorted[2] != sorted[0]+2 {
+			return invalid
+		}
+	case Corner:
+		if len(sorted) != 4 {
+			return invalid
+		}
+		if key == "[0 1 2 3]" && !american {
+			return nil
+		}
+		a := sorted[0]
+		if a == 0 || a%3 == 0 || sorted[1] != a+1 || sorted[2] != a+3 || sorted[3] != a+4 {
+			return invalid
+		}
+	case Basket:
+		if !american || key != "[0 1 2 3 37]" {
+			return invalid
+		}
+	case SixLine:
+		if len(sorted) != 6 {
+			return invalid
+		}
+		a := sorted[0]
+		if a == 0 || a%3 != 1 {
+			return invalid
+		}
+		for i, n := range sorted {
+			if n != a+i {
+				return invalid
+			}
+		}
+	default:
+		return fmt.Errorf("%s is not an inside bet", kind)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package roulette
+
+import (
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+func TestParseBets(t *testing.T) {
+	american := Classic{}
+	bets, err := ParseBets("high:100,dozen 3,split 0-00,street 7-8-9:12.5", american)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		text   string
+		amount casino.Money
+	}{{"high", casino.Dollars(100)}, {"dozen 3", 0}, {"split 0-00", 0}, {"street 7-8-9", 1250}}
+	if len(bets) != len(want) {
+		t.Fatalf("got %d bets, want %d", len(bets), len(want))
+	}
+	for i, w := range want {
+		if bets[i].String() != w.text || bets[i].Amount != w.amount {
+			t.Errorf("bet %d = %s %s, want %s %s", i, bets[i], bets[i].Amount, w.text, w.amount)
+		}
+	}
+
+	european := Classic{European: true}
+	for _, text := range []string{"", "dozen 4", "dozen", "split 1-5", "split 0-00", "red 1", "corner 1-2-3-4", "bogus 1", "high:0"} {
+		if _, err := ParseBets(text, european); err == nil {
+			t.Errorf("ParseBets(%q) should fail on a European layout", text)
+		}
+	}
+}
+
+func TestTableLimitsCheck(t *testing.T) {
+	limits := TableLimits{
+		Inside:  Limit{Min: casino.Dollars(1), Max: casino.Dollars(100)},
+		Outside: Limit{Min: casino.Dollars(5)},
+		Total:   Limit{Max: casino.Dollars(200)},
+	}
+	layout := func(text string) []Bet {
+		bets, err := ParseBets(text, Classic{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bets
+	}
+	if err := limits.Check(layout("red:50,straight 17:100")); err != nil {
+		t.Errorf("Check refused bets within the limits: %v", err)
+	}
+	for _, text := range []string{"straight 17:150", "red:2", "red:150,split 17-20:60"} {
+		if err := limits.Check(layout(text)); err == nil {
+			t.Errorf("Check accepted %q", text)
+		}
+	}
+	if Color(0) != "green" || Color(37) != "green" || Color(1) != "red" || Color(2) != "black" {
+		t.Errorf("Color gives 0 %s, 00 %s, 1 %s, 2 %s", Color(0), Color(37), Color(1), Color(2))
+	}
+}
@@ -0,0 +1,210 @@
+package roulette
+
+import (
+	"math"
+	"sort"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// ChiSquareResult is the outcome of a goodness-of-fit test on a spin log
+type ChiSquareResult struct {
+	Statistic        float64
+	DegreesOfFreedom int
+	PValue           float64
+}
+
+// PocketScore compares how often a pocket came up with how often it should have
+type PocketScore struct {
+	Pocket   int
+	Count    int
+	Expected float64
+	Z        float64
+}
+
+// SectorScore compares the hits on a run of neighbouring pockets with the expectation
+type SectorScore struct {
+	Pockets  []int
+	Count    int
+	Expected float64
+	Z        float64
+}
+
+// ExploitResult is the outcome of one bias-exploiting session
+type ExploitResult struct {
+	Targets []int // Pockets chosen from the observed spins
+	Staked  casino.Money
+	Net     casino.Money
+}
+
+// Counts tallies how many times each pocket came up
+func Counts(spins []int, pockets int) []int {
+	counts := make([]int, pockets)
+	for _, pocket := range spins {
+		counts[pocket]++
+	}
+	return counts
+}
+
+// total adds up the counts
+func total(counts []int) int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
+
+// ChiSquare tests the counts against the pocket probabilities
+func ChiSquare(counts []int, probabilities []float64) ChiSquareResult {
+	n := float64(total(counts))
+	statistic := 0.0
+	for i, c := range counts {
+		expected := n * probabilities[i]
+		statistic += math.Pow(float64(c)-expected, 2) / expected
+	}
+	df := len(counts) - 1
+	return ChiSquareResult{
+		Statistic:        statistic,
+		DegreesOfFreedom: df,
+		PValue:           gammaQ(float64(df)/2, statistic/2),
+	}
+}
+
+// zScore returns the standard score of a binomial count
+func zScore(count, n int, p float64) float64 {
+	expected := float64(n) * p
+	return (float64(count) - expected) / math.Sqrt(expected*(1-p))
+}
+
+// PocketZScores scores every pocket, hottest first
+func PocketZScores(counts []int, probabilities []float64) []PocketScore {
+	n := total(counts)
+	scores := make([]PocketScore, len(counts))
+	for i, c := range counts {
+		scores[i] = PocketScore{Pocket: i, Count: c, Expected: float64(n) * probabilities[i], Z: zScore(c, n, probabilities[i])}
+	}
+	sort.SliceStable(scores, func(a, b int) bool { return scores[a].Z > scores[b].Z })
+	return scores
+}
+
+// SectorZScores scores every run of width neighbouring pockets around the
+// wheel, hottest first
+func SectorZScores(counts []int, probabilities []float64, order []int, width int) []SectorScore {
+	n := total(counts)
+	scores := make([]SectorScore, len(order))
+	for start := range order {
+		sector := SectorScore{}
+		p := 0.0
+		for i := 0; i < width; i++ {
+			pocket := order[(start+i)%len(order)]
+			sector.Pockets = append(sector.Pockets, pocket)
+			sector.Count += counts[pocket]
+			p += probabilities[pocket]
+		}
+		sector.Expected = float64(n) * p
+		sector.Z = zScore(sector.Count, n, p)
+		scores[start] = sector
+	}
+	sort.SliceStable(scores, func(a, b int) bool { return scores[a].Z > scores[b].Z })
+	return scores
+}
+
+// SpinsToDetect estimates how many spins it takes to flag the most biased
+// pocket with a one-sided z-test at the given significance and power. The
+// significance is split across the pockets (Bonferroni) because the
+// detector looks at every pocket. It returns 0 if no pocket is favoured.
+func SpinsToDetect(fair, biased []float64, alpha, power float64) int {
+	zAlpha := normalQuantile(1 - alpha/float64(len(fair)))
+	zBeta := normalQuantile(power)
+
+	best := 0.0
+	for i := range fair {
+		p0, p1 := fair[i], biased[i]
+		if p1 <= p0 {
+			continue
+		}
+		n := math.Pow((zAlpha*math.Sqrt(p0*(1-p0))+zBeta*math.Sqrt(p1*(1-p1)))/(p1-p0), 2)
+		if best == 0 || n < best {
+			best = n
+		}
+	}
+	return int(math.Ceil(best))
+}
+
+// ExploitBias plays one session against a wheel: it watches learnSpins
+// spins, picks the targets most frequent pockets and then bets unit on
+// each of them straight up for betSpins spins
+func ExploitBias(wheel *Wheel, learnSpins, betSpins, targets int, unit casino.Money) ExploitResult {
+	pockets := Pockets(wheel.European)
+	counts := make([]int, pockets)
+	for i := 0; i < learnSpins; i++ {
+		counts[wheel.Spin()]++
+	}
+
+	order := Range(0, pockets-1)
+	sort.SliceStable(order, func(a, b int) bool { return counts[order[a]] > counts[order[b]] })
+	result := ExploitResult{Targets: order[:targets]}
+
+	table := Classic{European: wheel.European}
+	for i := 0; i < betSpins; i++ {
+		round := Round{Balls: []int{wheel.Spin()}}
+		for _, target := range result.Targets {
+			settlement := table.Settle(Bet{Kind: Straight, Numbers: []int{target}, Amount: unit}, round)
+			result.Staked += settlement.Stake
+			result.Net += settlement.Net
+		}
+	}
+	return result
+}
+
+// gammaQ is the regularized upper incomplete gamma function Q(a, x)
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	if x < a+1 {
+		// Series expansion of P(a, x)
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lgamma)
+	}
+
+	// Continued fraction for Q(a, x)
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lgamma) * h
+}
+
+// normalQuantile is the inverse of the standard normal distribution function
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
@@ -0,0 +1,120 @@
+package roulette
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGammaQ(t *testing.T) {
+	// Upper tail p-values of the chi-square distribution from a printed table
+	for _, tc := range []struct {
+		df        int
+		statistic float64
+		p         float64
+	}{
+		{1, 3.841, 0.05},
+		{1, 6.635, 0.01},
+		{10, 18.307, 0.05},
+		{10, 23.209, 0.01},
+		{36, 50.998, 0.05},
+		{36, 58.619, 0.01},
+		{37, 52.192, 0.05},
+		{37, 59.893, 0.01},
+	} {
+		if got := gammaQ(float64(tc.df)/2, tc.statistic/2); math.Abs(got-tc.p) > 2e-4 {
+			t.Errorf("chi-square %v on %d degrees of freedom: p = %v, want %v", tc.statistic, tc.df, got, tc.p)
+		}
+	}
+
+	// Q(1, x) is exp(-x), on both the series and the continued fraction side
+	for _, x := range []float64{0.1, 1, 1.9, 2.5, 10} {
+		if got, want := gammaQ(1, x), math.Exp(-x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Q(1, %v) = %v, want %v", x, got, want)
+		}
+	}
+	if got := gammaQ(3, 0); got != 1 {
+		t.Errorf("Q(3, 0) = %v, want 1", got)
+	}
+}
+
+func TestChiSquare(t *testing.T) {
+	// 60 heads and 40 tails is a statistic of 4 on one degree of freedom,
+	// which is a two-sided z of 2
+	result := ChiSquare([]int{60, 40}, []float64{0.5, 0.5})
+	if result.Statistic != 4 || result.DegreesOfFreedom != 1 {
+		t.Fatalf("ChiSquare = %+v, want a statistic of 4 on 1 degree of freedom", result)
+	}
+	if want := math.Erfc(math.Sqrt2); math.Abs(result.PValue-want) > 1e-9 {
+		t.Errorf("p = %v, want %v", result.PValue, want)
+	}
+
+	// Counts that match the expectation exactly cannot reject anything
+	counts := make([]int, 37)
+	fair := make([]float64, 37)
+	for i := range counts {
+		counts[i], fair[i] = 10, 1.0/37
+	}
+	if result := ChiSquare(counts, fair); math.Abs(result.Statistic) > 1e-9 || result.DegreesOfFreedom != 36 || math.Abs(result.PValue-1) > 1e-9 {
+		t.Errorf("ChiSquare of a perfectly even log = %+v", result)
+	}
+}
+
+func TestSectorZScores(t *testing.T) {
+	// 10 spins in every pocket and 30 more in 17, which sits between 25
+	// and 34 on a European wheel
+	counts := make([]int, 37)
+	fair := make([]float64, 37)
+	for i := range counts {
+		counts[i], fair[i] = 10, 1.0/37
+	}
+	counts[17] += 30
+
+	scores := SectorZScores(counts, fair, WheelOrder(true), 3)
+	if len(scores) != 37 {
+		t.Fatalf("%d sectors, want 37", len(scores))
+	}
+	expected := 400 * 3.0 / 37
+	z := (60 - expected) / math.Sqrt(expected*34/37)
+	for _, sector := range scores[:3] {
+		hot := false
+		for _, pocket := range sector.Pockets {
+			hot = hot || pocket == 17
+		}
+		if !hot || sector.Count != 60 || math.Abs(sector.Expected-expected) > 1e-9 || math.Abs(sector.Z-z) > 1e-9 {
+			t.Errorf("sector %+v, want one of the three holding 17 with 60 spins, %.3f expected and z %.3f", sector, expected, z)
+		}
+	}
+	if scores[3].Count != 30 {
+		t.Errorf("fourth sector %+v, want a cold sector of 30 spins", scores[3])
+	}
+
+	// Sectors wrap around the zero
+	last := SectorZScores(counts, fair, WheelOrder(true), 2)
+	for _, sector := range last {
+		if sector.Pockets[0] == 26 && sector.Pockets[1] != 0 {
+			t.Errorf("sector from 26 holds %v, want it to wrap to 0", sector.Pockets)
+		}
+	}
+}
+
+func TestSpinsToDetect(t *testing.T) {
+	// One pocket at twice the fair rate on a European wheel. At a level of
+	// 0.37 over 37 pockets the one-sided critical value is z(0.99), and at
+	// a power of one half the biased pocket contributes no z of its own, so
+	// n = (z(0.99) * sqrt(p0(1-p0)) / (p1-p0))^2 = (6 * 2.326348)^2
+	fair := make([]float64, 37)
+	biased := make([]float64, 37)
+	for i := range fair {
+		fair[i] = 1.0 / 37
+		biased[i] = (35.0 / 36) / 37
+	}
+	biased[17] = 2.0 / 37
+	if got := SpinsToDetect(fair, biased, 0.37, 0.5); got != 195 {
+		t.Errorf("SpinsToDetect = %d, want 195", got)
+	}
+
+	// A fair wheel has nothing to detect
+	if got := SpinsToDetect(fair, fair, 0.01, 0.9); got != 0 {
+		t.Errorf("SpinsToDetect of a fair wheel = %d, want 0", got)
+	}
+}
@@ -0,0 +1,77 @@
+package roulette
+
+import (
+	casino "github.com/BryceWayne/casino"
+)
+
+// Bankrolled is a progression that sizes its stake from the balance
+type Bankrolled interface {
+	Progression
+	// SetBalance tells the progression the balance before the next bet
+	SetBalance(balance casino.Money)
+}
+
+// SetBalance passes the balance to every wager sized from it
+func SetBalance(wagers []*Wager, balance casino.Money) {
+	for _, w := range wagers {
+		if b, ok := w.Progression.(Bankrolled); ok {
+			b.SetBalance(balance)
+		}
+	}
+}
+
+// kellyStake stakes a Kelly share of the balance
+type kellyStake struct {
+	sizing  casino.Kelly
+	balance casino.Money
+}
+
+func (k *kellyStake) Stake() casino.Money { return k.sizing.Stake(k.balance) }
+
+// Record keeps the balance up to date between calls to SetBalance
+func (k *kellyStake) Record(net casino.Money) { k.balance += net }
+
+func (k *kellyStake) Reset() {}
+
+func (k *kellyStake) SetBalance(balance casino.Money) { k.balance = balance }
+
+// BetOdds returns the edge and variance per unit staked of a single ball
+// bet, given the probability of each pocket
+func BetOdds(v Variant, bet Bet, probabilities []float64) (float64, float64) {
+	bet.Amount = casino.Dollar
+	mean, square := 0.0, 0.0
+	for pocket, p := range probabilities {
+		settlement := v.Settle(bet, Round{Balls: []int{pocket}})
+		x := float64(settlement.Net) / float64(settlement.Stake)
+		mean += p * x
+		square += p * x * x
+	}
+	return mean, square - mean*mean
+}
+
+// EstimateProbabilities spins the source and returns how often each pocket
+// came up, for wheels such as the physics model whose odds are not known
+func EstimateProbabilities(source SpinSource, pockets, spins int) []float64 {
+	counts := make([]int, pockets)
+	for i := 0; i < spins; i++ {
+		counts[source.Spin()]++
+	}
+	probabilities := make([]float64, pockets)
+	for pocket, count := range counts {
+		probabilities[pocket] = float64(count) / float64(spins)
+	}
+	return probabilities
+}
+
+// KellyFor fills in the edge and variance the sizing does not give from
+// the odds of the bet on a wheel with the given pocket probabilities
+func KellyFor(v Variant, bet Bet, probabilities []float64, sizing casino.Kelly) casino.Kelly {
+	edge, variance := BetOdds(v, bet, probabilities)
+	if sizing.Edge == 0 {
+		sizing.Edge = edge
+	}
+	if sizing.Variance == 0 {
+		sizing.Variance = variance
+	}
+	return sizing
+}
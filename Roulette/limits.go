@@ -0,0 +1,180 @@
+package roulette
+
+import (
+	"fmt"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// BetClass separates inside bets on the numbers from outside bets
+type BetClass int
+
+const (
+	Inside BetClass = iota
+	Outside
+)
+
+// Limit is a table minimum and maximum; a zero Max means no maximum
+type Limit struct {
+	Min casino.Money
+	Max casino.Money
+}
+
+// TableLimits holds the limits for each inside bet, each outside bet and
+// the total staked on the layout in a single spin
+type TableLimits struct {
+	Inside  Limit
+	Outside Limit
+	Total   Limit
+}
+
+// LimitRule decides how a progression responds to a bet outside the limits
+type LimitRule string
+
+const (
+	LimitCap     LimitRule = "cap"     // Bet the nearest amount the table allows
+	LimitQuit    LimitRule = "quit"    // End the session
+	LimitRestart LimitRule = "restart" // Reset the progression to its first bet
+)
+
+// ParseLimitRule checks a limit rule given on the command line
+func ParseLimitRule(s string) (LimitRule, error) {
+	switch rule := LimitRule(s); rule {
+	case LimitCap, LimitQuit, LimitRestart:
+		return rule, nil
+	}
+	return "", fmt.Errorf("unknown limit rule %q", s)
+}
+
+// Wager is a bet on the layout whose amount is set by a progression
+type Wager struct {
+	Bet
+	Progression Progression
+	AtLimit     LimitRule
+}
+
+// fits reports whether the amount is within the limit
+func (l Limit) fits(amount casino.Money) bool {
+	return amount >= l.Min && (l.Max == 0 || amount <= l.Max)
+}
+
+// clamp moves the amount into the limit
+func (l Limit) clamp(amount casino.Money) casino.Money {
+	if l.Max > 0 && amount > l.Max {
+		return l.Max
+	}
+	if amount < l.Min {
+		return l.Min
+	}
+	return amount
+}
+
+// String describes the limit, such as "$5 to $500"
+func (l Limit) String() string {
+	if l.Max == 0 {
+		return fmt.Sprintf("%s and up", l.Min)
+	}
+	return fmt.Sprintf("%s to %s", l.Min, l.Max)
+}
+
+// Check reports the first bet outside the table limits, or a layout total
+// outside them, for bets placed by hand rather than by a progression
+func (t TableLimits) Check(bets []Bet) error {
+	var total casino.Money
+	for _, bet := range bets {
+		if limit := t.limit(bet.Class()); !limit.fits(bet.Amount) {
+			return fmt.Errorf("%s %s is outside the table limit of %s", bet, bet.Amount, limit)
+		}
+		total += bet.Amount
+	}
+	if !t.Total.fits(total) {
+		return fmt.Errorf("a total of %s is outside the table limit of %s", total, t.Total)
+	}
+	return nil
+}
+
+// limit returns the limit for a single bet of the class
+func (t TableLimits) limit(class BetClass) Limit {
+	if class == Inside {
+		return t.Inside
+	}
+	return t.Outside
+}
+
+// Place asks every progression for its next stake and enforces the table
+// limits, applying each wager's limit rule to bets that break them. It
+// returns false when the session should end, either because a progression
+// has stopped or because a limit could not be met.
+func (t TableLimits) Place(wagers []*Wager) bool {
+	if len(wagers) == 0 {
+		return false
+	}
+
+	var total casino.Money
+	for _, w := range wagers {
+		w.Amount = w.Progression.Stake()
+		if w.Amount == 0 {
+			return false
+		}
+
+		limit := t.limit(w.Class())
+		if !limit.fits(w.Amount) {
+			switch w.AtLimit {
+			case LimitCap:
+				w.Amount = limit.clamp(w.Amount)
+			case LimitRestart:
+				w.Progression.Reset()
+				w.Amount = w.Progression.Stake()
+			}
+			if w.Amount == 0 || !limit.fits(w.Amount) {
+				return false
+			}
+		}
+		total += w.Amount
+	}
+
+	// Bring the layout total within the table limit, one bet at a time
+	for t.Total.Max > 0 && total > t.Total.Max {
+		w := largest(wagers)
+		excess := total - t.Total.Max
+		switch w.AtLimit {
+		case LimitCap:
+			reduced := t.limit(w.Class()).clamp(w.Amount - excess)
+			if reduced >= w.Amount {
+				return false
+			}
+			total -= w.Amount - reduced
+			w.Amount = reduced
+		case LimitRestart:
+			w.Progression.Reset()
+			restarted := w.Progression.Stake()
+			if restarted >= w.Amount {
+				return false
+			}
+			total -= w.Amount - restarted
+			w.Amount = restarted
+		default:
+			return false
+		}
+	}
+	if total < t.Total.Min {
+		w := largest(wagers)
+		raised := t.limit(w.Class()).clamp(w.Amount + t.Total.Min - total)
+		if w.AtLimit != LimitCap || total+raised-w.Amount < t.Total.Min {
+			return false
+		}
+		w.Amount = raised
+	}
+	return true
+}
+
+// largest returns the wager with the biggest stake
+func largest(wagers []*Wager) *Wager {
+	biggest := wagers[0]
+	for _, w := range wagers[1:] {
+		if w.Amount > biggest.Amount {
+			biggest = w
+		}
+	}
+	return biggest
+}
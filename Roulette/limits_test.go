@@ -0,0 +1,106 @@
+package roulette
+
+import (
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// wagerSpec is a Martingale from $10 that has lost losses times in a row,
+// so it stakes $10 doubled that many times
+type wagerSpec struct {
+	kind   Kind
+	losses int
+	rule   LimitRule
+}
+
+func (s wagerSpec) wager(t *testing.T) *Wager {
+	t.Helper()
+	progression, err := NewProgression(ProgressionConfig{System: Martingale, Unit: casino.Dollars(10), MaxSteps: 3, AtEnd: StopOnEnd})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < s.losses; i++ {
+		progression.Record(-progression.Stake())
+	}
+	bet := Bet{Kind: s.kind}
+	if s.kind == Straight {
+		bet.Numbers = []int{17}
+	}
+	return &Wager{Bet: bet, Progression: progression, AtLimit: s.rule}
+}
+
+func TestTableLimitsPlace(t *testing.T) {
+	limits := TableLimits{
+		Inside:  Limit{Min: casino.Dollars(1), Max: casino.Dollars(25)},
+		Outside: Limit{Min: casino.Dollars(5), Max: casino.Dollars(30)},
+	}
+	withTotal := func(total Limit) TableLimits {
+		l := limits
+		l.Total = total
+		return l
+	}
+	withOutside := func(outside Limit) TableLimits {
+		l := limits
+		l.Outside = outside
+		return l
+	}
+
+	cases := []struct {
+		name    string
+		limits  TableLimits
+		wagers  []wagerSpec
+		ok      bool
+		amounts []int // Dollars staked on each wager when ok
+		next    []int // Dollars each progression stakes next, to tell a restart from a cap
+	}{
+		{"within the limits", limits, []wagerSpec{{Red, 1, LimitQuit}, {Straight, 0, LimitQuit}}, true, []int{20, 10}, []int{20, 10}},
+		{"no wagers", limits, nil, false, nil, nil},
+		{"progression stopped", limits, []wagerSpec{{Red, 3, LimitCap}}, false, nil, nil},
+
+		// A bet over its own maximum
+		{"cap over the maximum", limits, []wagerSpec{{Red, 2, LimitCap}}, true, []int{30}, []int{40}},
+		{"quit over the maximum", limits, []wagerSpec{{Red, 2, LimitQuit}}, false, nil, nil},
+		{"restart over the maximum", limits, []wagerSpec{{Red, 2, LimitRestart}}, true, []int{10}, []int{10}},
+		{"inside maximum", limits, []wagerSpec{{Straight, 2, LimitCap}, {Red, 2, LimitCap}}, true, []int{25, 30}, []int{40, 40}},
+
+		// A bet under its own minimum
+		{"cap under the minimum", withOutside(Limit{Min: casino.Dollars(15)}), []wagerSpec{{Red, 0, LimitCap}}, true, []int{15}, []int{10}},
+		{"quit under the minimum", withOutside(Limit{Min: casino.Dollars(15)}), []wagerSpec{{Red, 0, LimitQuit}}, false, nil, nil},
+		{"restart under the minimum", withOutside(Limit{Min: casino.Dollars(15)}), []wagerSpec{{Red, 0, LimitRestart}}, false, nil, nil},
+
+		// A layout over the total maximum is brought down from its largest bet
+		{"cap the total", withTotal(Limit{Max: casino.Dollars(40)}), []wagerSpec{{Red, 1, LimitCap}, {Black, 2, LimitCap}}, true, []int{20, 20}, []int{20, 40}},
+		{"restart for the total", withTotal(Limit{Max: casino.Dollars(40)}), []wagerSpec{{Red, 1, LimitRestart}, {Black, 2, LimitRestart}}, true, []int{20, 10}, []int{20, 10}},
+		{"quit for the total", withTotal(Limit{Max: casino.Dollars(25)}), []wagerSpec{{Red, 1, LimitQuit}, {Black, 0, LimitCap}}, false, nil, nil},
+		{"cap cannot reach the total", TableLimits{Outside: Limit{Min: casino.Dollars(15)}, Total: Limit{Max: casino.Dollars(25)}}, []wagerSpec{{Red, 1, LimitCap}, {Black, 1, LimitCap}}, false, nil, nil},
+		{"restart cannot reach the total", withTotal(Limit{Max: casino.Dollars(15)}), []wagerSpec{{Red, 0, LimitRestart}, {Black, 0, LimitRestart}}, false, nil, nil},
+
+		// A layout under the total minimum is made up on its largest bet
+		{"cap to the total minimum", withTotal(Limit{Min: casino.Dollars(25)}), []wagerSpec{{Red, 0, LimitCap}, {Straight, 0, LimitCap}}, true, []int{15, 10}, []int{10, 10}},
+		{"quit under the total minimum", withTotal(Limit{Min: casino.Dollars(25)}), []wagerSpec{{Red, 0, LimitQuit}, {Straight, 0, LimitQuit}}, false, nil, nil},
+		{"total minimum past a bet maximum", withTotal(Limit{Min: casino.Dollars(50)}), []wagerSpec{{Red, 0, LimitCap}, {Straight, 0, LimitCap}}, false, nil, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			wagers := make([]*Wager, len(c.wagers))
+			for i, spec := range c.wagers {
+				wagers[i] = spec.wager(t)
+			}
+			if ok := c.limits.Place(wagers); ok != c.ok {
+				t.Fatalf("Place = %v, want %v", ok, c.ok)
+			}
+			if !c.ok {
+				return
+			}
+			for i, w := range wagers {
+				if w.Amount != casino.Dollars(c.amounts[i]) {
+					t.Errorf("wager %d stakes %s, want $%d", i, w.Amount, c.amounts[i])
+				}
+				if next := w.Progression.Stake(); next != casino.Dollars(c.next[i]) {
+					t.Errorf("wager %d progression stakes %s next, want $%d", i, next, c.next[i])
+				}
+			}
+		})
+	}
+}
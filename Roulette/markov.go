@@ -0,0 +1,245 @@
+package roulette
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// ChainBet is one bet of a progression layout in the Markov-chain model
+type ChainBet struct {
+	Numbers []int // Pockets covered by the bet
+	Payout  int   // Net winnings per unit staked when the bet wins
+}
+
+// Chain describes a progression session as an absorbing Markov chain.
+// A state is the current balance together with the progression step of
+// every bet. Each spin every bet stakes Steps[step]; a win resets the bet
+// to step 0 and a loss advances it, wrapping back to step 0 after the last
+// step. The session ends exactly like the simulators: it succeeds once the
+// balance reaches Balance+ProfitGoal and fails when the balance drops to 0,
+// falls below StopLoss, or the stakes of the next spin add up to more than
+// the remaining balance.
+type Chain struct {
+	Bets       []ChainBet
+	Steps      []casino.Money
+	Pockets    int
+	Balance    casino.Money
+	ProfitGoal casino.Money
+	StopLoss   casino.Money
+}
+
+// ChainResult holds the solution of a Chain
+type ChainResult struct {
+	SuccessProbability float64 // Probability of reaching the profit goal
+	ExpectedSpins      float64 // Expected number of spins until the session ends
+	States             int     // Number of transient states in the chain
+	Iterations         int     // Sweeps needed to converge
+}
+
+// chainState is a transient state of the chain
+type chainState struct {
+	balance casino.Money
+	steps   string // One byte per bet holding its progression step
+}
+
+// chainOutcome groups the pockets that settle every bet the same way
+type chainOutcome struct {
+	wins        []bool
+	probability float64
+}
+
+// Validate checks that the chain can be built
+func (c Chain) Validate() error {
+	if len(c.Bets) == 0 {
+		return errors.New("chain needs at least one bet")
+	}
+	if len(c.Steps) == 0 || len(c.Steps) > math.MaxUint8 {
+		return fmt.Errorf("chain needs between 1 and %d steps", math.MaxUint8)
+	}
+	for _, step := range c.Steps {
+		if step <= 0 {
+			return fmt.Errorf("step %s must be positive", step)
+		}
+	}
+	if c.Pockets <= 0 {
+		return errors.New("wheel needs at least one pocket")
+	}
+	for _, bet := range c.Bets {
+		for _, n := range bet.Numbers {
+			if n < 0 || n >= c.Pockets {
+				return fmt.Errorf("pocket %d is not on a %d pocket wheel", n, c.Pockets)
+			}
+		}
+	}
+	if c.Balance <= 0 || c.ProfitGoal <= 0 {
+		return errors.New("balance and profit goal must be positive")
+	}
+	return nil
+}
+
+// outcomes collapses the wheel into the distinct ways the bets can settle
+func (c Chain) outcomes() []chainOutcome {
+	counts := map[string]int{}
+	var order []string
+	for pocket := 0; pocket < c.Pockets; pocket++ {
+		key := make([]byte, len(c.Bets))
+		for i, bet := range c.Bets {
+			key[i] = '0'
+			for _, n := range bet.Numbers {
+				if n == pocket {
+					key[i] = '1'
+					break
+				}
+			}
+		}
+		if counts[string(key)] == 0 {
+			order = append(order, string(key))
+		}
+		counts[string(key)]++
+	}
+
+	outcomes := make([]chainOutcome, 0, len(order))
+	for _, key := range order {
+		wins := make([]bool, len(key))
+		for i := range key {
+			wins[i] = key[i] == '1'
+		}
+		outcomes = append(outcomes, chainOutcome{wins: wins, probability: float64(counts[key]) / float64(c.Pockets)})
+	}
+	return outcomes
+}
+
+// absorbed reports whether a state ends the session and whether it is a success
+func (c Chain) absorbed(s chainState) (done bool, success bool) {
+	if s.balance >= c.Balance+c.ProfitGoal {
+		return true, true
+	}
+	if s.balance <= 0 || s.balance < c.StopLoss {
+		return true, false
+	}
+	var staked casino.Money
+	for i := 0; i < len(s.steps); i++ {
+		staked += c.Steps[s.steps[i]]
+	}
+	if staked > s.balance {
+		return true, false
+	}
+	return false, false
+}
+
+// next returns the state reached from s when the spin settles as outcome
+func (c Chain) next(s chainState, outcome chainOutcome) chainState {
+	balance := s.balance
+	steps := []byte(s.steps)
+	for i, win := range outcome.wins {
+		stake := c.Steps[steps[i]]
+		if win {
+			balance += stake * casino.Money(c.Bets[i].Payout)
+			steps[i] = 0
+		} else {
+			balance -= stake
+			if int(steps[i]) < len(c.Steps)-1 {
+				steps[i]++
+			} else {
+				steps[i] = 0
+			}
+		}
+	}
+	return chainState{balance: balance, steps: string(steps)}
+}
+
+// Solve builds the reachable part of the chain and computes the success
+// probability and expected session length from the starting state. The
+// linear system is solved by Gauss-Seidel sweeps until no value changes by
+// more than tolerance.
+func (c Chain) Solve(tolerance float64, maxIterations int) (ChainResult, error) {
+	if err := c.Validate(); err != nil {
+		return ChainResult{}, err
+	}
+	outcomes := c.outcomes()
+
+	start := chainState{balance: c.Balance, steps: string(make([]byte, len(c.Bets)))}
+	if done, success := c.absorbed(start); done {
+		if success {
+			return ChainResult{SuccessProbability: 1}, nil
+		}
+		return ChainResult{}, nil
+	}
+
+	// Enumerate the transient states reachable from the start
+	index := map[chainState]int{start: 0}
+	states := []chainState{start}
+	for i := 0; i < len(states); i++ {
+		for _, outcome := range outcomes {
+			s := c.next(states[i], outcome)
+			if done, _ := c.absorbed(s); done {
+				continue
+			}
+			if _, ok := index[s]; !ok {
+				index[s] = len(states)
+				states = append(states, s)
+			}
+		}
+	}
+
+	// Sweep from the richest states down so successes propagate quickly
+	order := make([]int, len(states))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return states[order[a]].balance > states[order[b]].balance
+	})
+
+	// Precompute transitions: -1 is a failure, -2 a success
+	transitions := make([][]int, len(states))
+	for i, s := range states {
+		transitions[i] = make([]int, len(outcomes))
+		for j, outcome := range outcomes {
+			n := c.next(s, outcome)
+			if done, success := c.absorbed(n); done {
+				transitions[i][j] = -1
+				if success {
+					transitions[i][j] = -2
+				}
+				continue
+			}
+			transitions[i][j] = index[n]
+		}
+	}
+
+	success := make([]float64, len(states))
+	spins := make([]float64, len(states))
+	for iteration := 1; iteration <= maxIterations; iteration++ {
+		delta := 0.0
+		for _, i := range order {
+			p, t := 0.0, 1.0
+			for j, target := range transitions[i] {
+				switch target {
+				case -2:
+					p += outcomes[j].probability
+				case -1:
+				default:
+					p += outcomes[j].probability * success[target]
+					t += outcomes[j].probability * spins[target]
+				}
+			}
+			delta = math.Max(delta, math.Max(math.Abs(p-success[i]), math.Abs(t-spins[i])))
+			success[i], spins[i] = p, t
+		}
+		if delta <= tolerance {
+			return ChainResult{
+				SuccessProbability: success[0],
+				ExpectedSpins:      spins[0],
+				States:             len(states),
+				Iterations:         iteration,
+			}, nil
+		}
+	}
+
+	return ChainResult{}, fmt.Errorf("chain did not converge within %d iterations", maxIterations)
+}
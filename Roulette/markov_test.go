@@ -0,0 +1,60 @@
+package roulette
+
+import (
+	"math"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+func TestChainGamblersRuin(t *testing.T) {
+	// A flat unit on an even-money bet is the gambler's ruin, which has a
+	// closed form: with r = q/p, starting from i units and stopping at 0 or
+	// n, the chance of reaching n is (1-r^i)/(1-r^n), and the expected
+	// number of bets is i/(q-p) - n/(q-p) * (1-r^i)/(1-r^n)
+	const i, n = 10, 25
+	p, q := 18.0/37, 19.0/37
+	r := q / p
+	success := (1 - math.Pow(r, i)) / (1 - math.Pow(r, n))
+	spins := i/(q-p) - n/(q-p)*success
+
+	chain := Chain{
+		Bets:       []ChainBet{{Numbers: Range(19, 36), Payout: 1}},
+		Steps:      []casino.Money{casino.Dollar},
+		Pockets:    37,
+		Balance:    casino.Dollars(i),
+		ProfitGoal: casino.Dollars(n - i),
+	}
+	result, err := chain.Solve(1e-13, 1_000_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.SuccessProbability-success) > 1e-9 || math.Abs(result.ExpectedSpins-spins) > 1e-7 {
+		t.Errorf("got %.10f in %.6f spins, want %.10f in %.6f", result.SuccessProbability, result.ExpectedSpins, success, spins)
+	}
+	if result.States != n-1 {
+		t.Errorf("%d states, want the %d balances between ruin and the goal", result.States, n-1)
+	}
+}
+
+func TestChainValidate(t *testing.T) {
+	valid := Chain{Bets: []ChainBet{{Numbers: []int{1}, Payout: 35}}, Steps: []casino.Money{casino.Dollar}, Pockets: 37, Balance: casino.Dollars(10), ProfitGoal: casino.Dollars(10)}
+	if err := valid.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, change := range map[string]func(*Chain){
+		"no bets":         func(c *Chain) { c.Bets = nil },
+		"no steps":        func(c *Chain) { c.Steps = nil },
+		"zero step":       func(c *Chain) { c.Steps = []casino.Money{0} },
+		"pocket off":      func(c *Chain) { c.Bets = []ChainBet{{Numbers: []int{37}, Payout: 35}} },
+		"no balance":      func(c *Chain) { c.Balance = 0 },
+		"no profit goal":  func(c *Chain) { c.ProfitGoal = 0 },
+		"no wheel at all": func(c *Chain) { c.Pockets = 0 },
+	} {
+		c := valid
+		change(&c)
+		if err := c.Validate(); err == nil {
+			t.Errorf("%s: chain should be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package roulette
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Physics models a spin from the ball and rotor dynamics instead of picking
+// a pocket uniformly. The dealer launches the ball from the same spot on
+// the track every spin. The ball slows down until it is too slow to stay
+// on the track, spirals down onto the rotor, and is scattered by the
+// deflectors before settling. The rotor turns the opposite way while it
+// slows down. Angles are in revolutions and speeds in revolutions per
+// second.
+type Physics struct {
+	European          bool
+	RotorSpeed        float64 // Rotor speed at launch
+	RotorDeceleration float64 // Rotor slow-down per second
+	BallSpeed         float64 // Ball speed at launch
+	BallSpread        float64 // Standard deviation of the launch speed
+	BallDeceleration  float64 // Ball slow-down per second on the track
+	DropSpeed         float64 // Speed below which the ball leaves a level track
+	FallTime          float64 // Seconds from leaving the track to reaching the pockets
+	Tilt              float64 // How much faster the ball leaves the track on the low side, as a fraction of DropSpeed
+	TiltAngle         float64 // Position of the low side of the track
+	Scatter           float64 // Standard deviation of the deflector bounce, in pockets
+	Signature         bool    // The dealer launches when the same pocket passes the launch spot
+	PhaseSpread       float64 // Standard deviation of that rotor position with a dealer signature
+	rng               random
+}
+
+// DefaultPhysics returns a level wheel with a typical spin and no dealer signature
+func DefaultPhysics(european bool) *Physics {
+	return &Physics{
+		European:          european,
+		RotorSpeed:        0.5,
+		RotorDeceleration: 0.005,
+		BallSpeed:         2.0,
+		BallSpread:        0.05,
+		BallDeceleration:  0.1,
+		DropSpeed:         0.8,
+		FallTime:          1.5,
+		Scatter:           4,
+		PhaseSpread:       0.01,
+	}
+}
+
+// ParsePhysics builds a physics model from the defaults and a list of
+// overrides such as "ball=2.2,tilt=0.02,scatter=3"
+func ParsePhysics(spec string, european bool) (*Physics, error) {
+	p := DefaultPhysics(european)
+	if strings.TrimSpace(spec) == "default" {
+		return p, nil
+	}
+
+	fields := map[string]*float64{
+		"rotor":      &p.RotorSpeed,
+		"rotordecel": &p.RotorDeceleration,
+		"ball":       &p.BallSpeed,
+		"ballspread": &p.BallSpread,
+		"balldecel":  &p.BallDeceleration,
+		"drop":       &p.DropSpeed,
+		"fall":       &p.FallTime,
+		"tilt":       &p.Tilt,
+		"tiltangle":  &p.TiltAngle,
+		"scatter":    &p.Scatter,
+		"phase":      &p.PhaseSpread,
+	}
+	for _, field := range strings.Split(spec, ",") {
+		parts := strings.Split(field, "=")
+		key := strings.TrimSpace(parts[0])
+		if key == "signature" && len(parts) == 1 {
+			p.Signature = true
+			continue
+		}
+		target, ok := fields[key]
+		if !ok || len(parts) != 2 {
+			return nil, fmt.Errorf("invalid physics setting %q", field)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid physics setting %q", field)
+		}
+		*target = value
+	}
+	return p, p.Validate()
+}
+
+// Validate checks that the ball can leave the track
+func (p *Physics) Validate() error {
+	if p.BallDeceleration <= 0 || p.DropSpeed <= 0 {
+		return fmt.Errorf("ball deceleration and drop speed must be positive")
+	}
+	if p.BallSpeed <= p.DropSpeed*(1+math.Abs(p.Tilt)) {
+		return fmt.Errorf("ball speed must be above the drop speed")
+	}
+	if p.RotorSpeed < 0 || p.RotorDeceleration < 0 || p.FallTime < 0 || p.Scatter < 0 || p.BallSpread < 0 || p.PhaseSpread < 0 {
+		return fmt.Errorf("speeds, times and spreads cannot be negative")
+	}
+	return nil
+}
+
+// dropTime returns when the ball leaves the track and where it is then
+func (p *Physics) dropTime(speed float64) (float64, float64) {
+	if p.Tilt == 0 {
+		t := math.Max(speed-p.DropSpeed, 0) / p.BallDeceleration
+		return t, speed*t - p.BallDeceleration*t*t/2
+	}
+
+	// On a tilted wheel the drop speed depends on where the ball is. The
+	// ball cannot leave the track while it is faster than the highest drop
+	// speed, so skip ahead to that point, then step along the track and
+	// interpolate the crossing
+	const step = 0.01
+	t := math.Max(speed-p.DropSpeed*(1+math.Abs(p.Tilt)), 0) / p.BallDeceleration
+	angle := speed*t - p.BallDeceleration*t*t/2
+	speed -= p.BallDeceleration * t
+	margin := speed - p.DropSpeed*(1+p.Tilt*math.Cos(2*math.Pi*(angle-p.TiltAngle)))
+	for margin > 0 {
+		nextAngle := angle + speed*step - p.BallDeceleration*step*step/2
+		nextSpeed := speed - p.BallDeceleration*step
+		nextMargin := nextSpeed - p.DropSpeed*(1+p.Tilt*math.Cos(2*math.Pi*(nextAngle-p.TiltAngle)))
+		if nextMargin <= 0 {
+			f := margin / (margin - nextMargin)
+			return t + f*step, angle + f*(nextAngle-angle)
+		}
+		t, angle, speed, margin = t+step, nextAngle, nextSpeed, nextMargin
+	}
+	return t, angle
+}
+
+// Spin returns the pocket the ball settles in
+func (p *Physics) Spin() int {
+	order := WheelOrder(p.European)
+	pockets := float64(len(order))
+
+	// Position of the rotor when the ball is launched
+	phase := p.rng.Float64()
+	if p.Signature {
+		phase = p.rng.NormFloat64() * p.PhaseSpread
+	}
+
+	speed := p.BallSpeed + p.rng.NormFloat64()*p.BallSpread
+	drop, angle := p.dropTime(speed)
+
+	// The ball keeps roughly its drop speed while it spirals down
+	landing := drop + p.FallTime
+	ballAngle := angle + p.DropSpeed*p.FallTime
+
+	// The rotor turns the other way, slowing down until it stops
+	rotorTime := landing
+	if p.RotorDeceleration > 0 {
+		rotorTime = math.Min(landing, p.RotorSpeed/p.RotorDeceleration)
+	}
+	rotorAngle := phase - (p.RotorSpeed*rotorTime - p.RotorDeceleration*rotorTime*rotorTime/2)
+
+	// Pocket under the ball, then scattered by the deflectors
+	relative := ballAngle - rotorAngle
+	relative -= math.Floor(relative)
+	index := int(relative * pockets)
+	index += int(math.Round(p.rng.NormFloat64() * p.Scatter))
+	index = ((index % len(order)) + len(order)) % len(order)
+	return order[index]
+}
+
+// WithRand returns a copy of the model drawing from the generator
+func (p *Physics) WithRand(rng *rand.Rand) SpinSource {
+	seeded := *p
+	seeded.rng = random{rng}
+	return &seeded
+}
+
+func (p *Physics) generator() random { return p.rng }
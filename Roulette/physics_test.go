@@ -0,0 +1,91 @@
+package roulette
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// physicsChiSquare spins the model n times and tests the counts against a
+// fair wheel
+func physicsChiSquare(t *testing.T, spec string, n int) ChiSquareResult {
+	t.Helper()
+	p, err := ParsePhysics(spec, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := p.WithRand(rand.New(rand.NewSource(1)))
+	counts := make([]int, 37)
+	for i := 0; i < n; i++ {
+		counts[source.Spin()]++
+	}
+	return ChiSquare(counts, (&Wheel{European: true}).Probabilities())
+}
+
+func TestPhysicsBias(t *testing.T) {
+	// A level wheel spun from a random rotor position is fair
+	if result := physicsChiSquare(t, "default", 20_000); result.PValue < 0.001 {
+		t.Errorf("level wheel without a signature: %+v, want it to look fair", result)
+	}
+
+	// A tilt alone still lands uniformly, since the rotor is anywhere when
+	// the ball drops
+	if result := physicsChiSquare(t, "tilt=0.05", 20_000); result.PValue < 0.001 {
+		t.Errorf("tilted wheel without a signature: %+v, want it to look fair", result)
+	}
+
+	// A dealer signature fixes the rotor, so the pocket is biased once the
+	// ball leaves the track at a predictable spot: because its launch speed
+	// hardly varies, or because a tilt makes it drop on the low side
+	for _, spec := range []string{"signature,ballspread=0.005", "signature,tilt=0.1"} {
+		if result := physicsChiSquare(t, spec, 2_000); result.PValue > 1e-6 {
+			t.Errorf("%s: %+v, want a clear bias", spec, result)
+		}
+	}
+}
+
+func TestPhysicsTiltDrop(t *testing.T) {
+	// The ball leaves a tilted track near its low side, where the drop
+	// speed is highest, and anywhere on a level one
+	clustering := func(p *Physics) float64 {
+		rng := rand.New(rand.NewSource(2))
+		sum := 0.0
+		const n = 2_000
+		for i := 0; i < n; i++ {
+			_, angle := p.dropTime(p.BallSpeed + rng.NormFloat64()*p.BallSpread)
+			sum += math.Cos(2 * math.Pi * (angle - p.TiltAngle))
+		}
+		return sum / n
+	}
+
+	level := DefaultPhysics(true)
+	tilted := DefaultPhysics(true)
+	tilted.Tilt, tilted.TiltAngle = 0.1, 0.3
+	if c := clustering(level); math.Abs(c) > 0.1 {
+		t.Errorf("level track: mean cosine from the low side %.3f, want about 0", c)
+	}
+	if c := clustering(tilted); c < 0.3 {
+		t.Errorf("tilted track: mean cosine from the low side %.3f, want the drops clustered there", c)
+	}
+
+	// On a level track the ball leaves exactly at the drop speed
+	drop, angle := level.dropTime(2)
+	if math.Abs(drop-12) > 1e-9 || math.Abs(angle-16.8) > 1e-9 {
+		t.Errorf("level drop at %v s and %v revolutions, want 12 s and 16.8", drop, angle)
+	}
+}
+
+func TestParsePhysics(t *testing.T) {
+	p, err := ParsePhysics("ball=2.2, tilt=0.02,signature", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.BallSpeed != 2.2 || p.Tilt != 0.02 || !p.Signature || p.European || p.Scatter != 4 {
+		t.Errorf("ParsePhysics = %+v", p)
+	}
+	for _, spec := range []string{"speed=2", "ball", "ball=fast", "ball=0.5", "drop=0", "scatter=-1", "tilt=2"} {
+		if _, err := ParsePhysics(spec, true); err == nil {
+			t.Errorf("ParsePhysics(%q) should fail", spec)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package roulette
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// Progression decides how much to stake on each spin
+type Progression interface {
+	// Stake returns the amount to bet on the next spin, or 0 to stop betting
+	Stake() casino.Money
+	// Record updates the progression with the net result of the last bet
+	Record(net casino.Money)
+	// Reset returns the progression to its first bet
+	Reset()
+}
+
+// System names a built-in betting system
+type System string
+
+const (
+	Flat        System = "flat"
+	Steps       System = "steps"
+	Martingale  System = "martingale"
+	Fibonacci   System = "fibonacci"
+	DAlembert   System = "dalembert"
+	Labouchere  System = "labouchere"
+	OscarsGrind System = "oscars_grind"
+	Paroli      System = "paroli"
+	Kelly       System = "kelly"
+)
+
+// EndRule decides what a progression does when it runs past its last step
+type EndRule string
+
+const (
+	RestartOnEnd EndRule = "restart" // Go back to the first step
+	CapAtEnd     EndRule = "cap"     // Keep betting the last step
+	StopOnEnd    EndRule = "stop"    // Stop betting
+)
+
+// ProgressionConfig describes a progression to build with NewProgression.
+// Negative progressions (step tables, Martingale, Fibonacci, D'Alembert)
+// advance a step on a loss. Martingale goes back to the unit on a win, and
+// the others step back: one step for step tables and D'Alembert, two for
+// Fibonacci. Paroli advances on a win and resets on a loss. A push leaves every progression unchanged.
+// Kelly ignores the unit and stakes a share of the balance, which the
+// caller passes in with SetBalance.
+type ProgressionConfig struct {
+	System     System
+	Unit       casino.Money    // Base bet amount
+	Steps      []casino.Money  // Step table for Steps, or the Labouchère line
+	MaxSteps   int             // Table length for Martingale, Fibonacci and Paroli, or the unit cap for D'Alembert and Oscar's Grind
+	ResetOnWin bool            // Return a negative progression to its first step on any win
+	AtEnd      EndRule         // What to do after the last step
+	Chips      casino.Chips    // Chips the stakes are made of; the zero value bets exact amounts
+	Rounding   casino.ChipRule // What to do with a stake the chips cannot make
+	Sizing     casino.Kelly    // Edge, variance, fraction and cap for the Kelly system
+}
+
+// ParseSteps parses a comma separated list of bet steps in dollars
+func ParseSteps(s string) ([]casino.Money, error) {
+	var steps []casino.Money
+	for _, field := range strings.Split(s, ",") {
+		step, err := casino.ParseMoney(field)
+		if err != nil || step <= 0 {
+			return nil, fmt.Errorf("invalid step %q", field)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// NewProgression builds a fresh progression from its configuration
+func NewProgression(cfg ProgressionConfig) (Progression, error) {
+	p, err := newSystem(cfg)
+	if err != nil || !cfg.Chips.Enabled() {
+		return p, err
+	}
+	if cfg.Rounding == "" {
+		cfg.Rounding = casino.ChipUp
+	}
+	if _, err := casino.ParseChipRule(string(cfg.Rounding)); err != nil {
+		return nil, err
+	}
+	return &rounded{Progression: p, chips: cfg.Chips, rule: cfg.Rounding}, nil
+}
+
+// newSystem builds the progression of a betting system
+func newSystem(cfg ProgressionConfig) (Progression, error) {
+	if cfg.AtEnd == "" {
+		cfg.AtEnd = RestartOnEnd
+	}
+	switch cfg.AtEnd {
+	case RestartOnEnd, CapAtEnd, StopOnEnd:
+	default:
+		return nil, fmt.Errorf("unknown end rule %q", cfg.AtEnd)
+	}
+	if cfg.System != Steps && cfg.System != Kelly && cfg.Unit <= 0 {
+		return nil, errors.New("progression unit must be positive")
+	}
+	maxSteps := cfg.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = 8
+	}
+
+	switch cfg.System {
+	case Flat:
+		return newTable(cfg, []casino.Money{cfg.Unit}, winReset, loseAdvance), nil
+	case Steps:
+		if len(cfg.Steps) == 0 {
+			return nil, errors.New("step table needs at least one step")
+		}
+		return newTable(cfg, cfg.Steps, winBack, loseAdvance), nil
+	case Martingale:
+		steps := make([]casino.Money, maxSteps)
+		for i := range steps {
+			steps[i] = cfg.Unit << i
+		}
+		return newTable(cfg, steps, winReset, loseAdvance), nil
+	case Fibonacci:
+		steps := make([]casino.Money, maxSteps)
+		a, b := 1, 1
+		for i := range steps {
+			steps[i] = casino.Money(a) * cfg.Unit
+			a, b = b, a+b
+		}
+		return newTable(cfg, steps, winBackTwo, loseAdvance), nil
+	case DAlembert:
+		steps := make([]casino.Money, maxSteps)
+		for i := range steps {
+			steps[i] = casino.Money(i+1) * cfg.Unit
+		}
+		return newTable(cfg, steps, winBack, loseAdvance), nil
+	case Paroli:
+		steps := make([]casino.Money, maxSteps)
+		for i := range steps {
+			steps[i] = cfg.Unit << i
+		}
+		return newTable(cfg, steps, winAdvance, loseReset), nil
+	case Labouchere:
+		line := cfg.Steps
+		if len(line) == 0 {
+			line = []casino.Money{cfg.Unit, 2 * cfg.Unit, 3 * cfg.Unit, 4 * cfg.Unit}
+		}
+		l := &labouchere{start: line, atEnd: cfg.AtEnd}
+		l.Reset()
+		return l, nil
+	case Kelly:
+		if err := cfg.Sizing.Validate(); err != nil {
+			return nil, err
+		}
+		return &kellyStake{sizing: cfg.Sizing}, nil
+	case OscarsGrind:
+		return &oscarsGrind{unit: cfg.Unit, maxUnits: maxSteps, atEnd: cfg.AtEnd, stake: cfg.Unit}, nil
+	}
+	return nil, fmt.Errorf("unknown progression system %q", cfg.System)
+}
+
+// stepMove is how a step table moves after a bet is settled
+type stepMove int
+
+const (
+	winReset stepMove = iota
+	winBack
+	winBackTwo
+	winAdvance
+	loseAdvance
+	loseReset
+)
+
+// table is a progression that walks a fixed table of stakes
+type table struct {
+	steps   []casino.Money
+	step    int
+	onWin   stepMove
+	onLose  stepMove
+	atEnd   EndRule
+	stopped bool
+}
+
+// newTable builds a table progression, applying the reset-on-win override
+func newTable(cfg ProgressionConfig, steps []casino.Money, onWin, onLose stepMove) *table {
+	if cfg.ResetOnWin && onLose == loseAdvance {
+		onWin = winReset
+	}
+	return &table{steps: steps, onWin: onWin, onLose: onLose, atEnd: cfg.AtEnd}
+}
+
+func (t *table) Stake() casino.Money {
+	if t.stopped {
+		return 0
+	}
+	return t.steps[t.step]
+}
+
+func (t *table) Record(net casino.Money) {
+	move := t.onLose
+	if net > 0 {
+		move = t.onWin
+	} else if net == 0 {
+		return
+	}
+
+	switch move {
+	case winReset, loseReset:
+		t.step = 0
+	case winBack:
+		if t.step > 0 {
+			t.step--
+		}
+	case winBackTwo:
+		if t.step -= 2; t.step < 0 {
+			t.step = 0
+		}
+	case winAdvance, loseAdvance:
+		if t.step < len(t.steps)-1 {
+			t.step++
+			return
+		}
+		switch t.atEnd {
+		case RestartOnEnd:
+			t.step = 0
+		case StopOnEnd:
+			t.stopped = true
+		}
+	}
+}
+
+func (t *table) Reset() {
+	t.step = 0
+	t.stopped = false
+}
+
+// labouchere stakes the sum of the first and last amounts of a line,
+// crossing them off on a win and appending the lost stake on a loss
+type labouchere struct {
+	start   []casino.Money
+	line    []casino.Money
+	atEnd   EndRule
+	stopped bool
+}
+
+func (l *labouchere) Stake() casino.Money {
+	if l.stopped {
+		return 0
+	}
+	if len(l.line) == 1 {
+		return l.line[0]
+	}
+	return l.line[0] + l.line[len(l.line)-1]
+}
+
+func (l *labouchere) Record(net casino.Money) {
+	switch {
+	case net > 0:
+		if len(l.line) <= 2 {
+			l.line = l.line[:0]
+		} else {
+			l.line = l.line[1 : len(l.line)-1]
+		}
+	case net < 0:
+		l.line = append(l.line, l.Stake())
+	}
+
+	// A cleared line completes the cycle
+	if len(l.line) == 0 {
+		if l.atEnd == StopOnEnd {
+			l.stopped = true
+			return
+		}
+		l.line = append(l.line, l.start...)
+	}
+}
+
+func (l *labouchere) Reset() {
+	l.line = append([]casino.Money(nil), l.start...)
+	l.stopped = false
+}
+
+// oscarsGrind aims to win one unit per cycle, raising the stake by a unit
+// after each win that leaves the cycle short of its goal
+type oscarsGrind struct {
+	unit     casino.Money
+	maxUnits int
+	atEnd    EndRule
+	stake    casino.Money
+	profit   casino.Money
+	stopped  bool
+}
+
+func (o *oscarsGrind) Stake() casino.Money {
+	if o.stopped {
+		return 0
+	}
+	return o.stake
+}
+
+func (o *oscarsGrind) Record(net casino.Money) {
+	o.profit += net
+	if o.profit >= o.unit {
+		// Cycle complete
+		o.stake, o.profit = o.unit, 0
+		return
+	}
+	if net <= 0 {
+		return
+	}
+
+	// Never stake more than is needed to finish the cycle
+	o.stake += o.unit
+	if o.stake > o.unit-o.profit {
+		o.stake = o.unit - o.profit
+	}
+	if o.stake > casino.Money(o.maxUnits)*o.unit {
+		switch o.atEnd {
+		case RestartOnEnd:
+			o.Reset()
+		case CapAtEnd:
+			o.stake = casino.Money(o.maxUnits) * o.unit
+		case StopOnEnd:
+			o.stopped = true
+		}
+	}
+}
+
+func (o *oscarsGrind) Reset() {
+	o.stake, o.profit = o.unit, 0
+	o.stopped = false
+}
+
+// rounded makes the stakes of a progression out of chips, stopping when
+// the rule refuses a stake
+type rounded struct {
+	Progression
+	chips casino.Chips
+	rule  casino.ChipRule
+}
+
+func (r *rounded) SetBalance(balance casino.Money) {
+	if b, ok := r.Progression.(Bankrolled); ok {
+		b.SetBalance(balance)
+	}
+}
+
+func (r *rounded) Stake() casino.Money {
+	stake := r.Progression.Stake()
+	if stake == 0 {
+		return 0
+	}
+	amount, ok := r.chips.Round(stake, r.rule)
+	if !ok {
+		return 0
+	}
+	return amount
+}
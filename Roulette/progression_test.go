@@ -0,0 +1,109 @@
+package roulette
+
+import (
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// stakes plays a progression through a run of results, W for a win, L for
+// a loss and P for a push, and returns the stake before each one and the
+// stake after the last, in dollars
+func stakes(p Progression, results string) []int64 {
+	var got []int64
+	for _, r := range results {
+		stake := p.Stake()
+		got = append(got, int64(stake/casino.Dollar))
+		switch r {
+		case 'W':
+			p.Record(stake)
+		case 'L':
+			p.Record(-stake)
+		case 'P':
+			p.Record(0)
+		}
+	}
+	return append(got, int64(p.Stake()/casino.Dollar))
+}
+
+func TestProgressions(t *testing.T) {
+	unit := casino.Dollars(10)
+	cases := []struct {
+		name    string
+		cfg     ProgressionConfig
+		results string
+		want    []int64
+	}{
+		{"flat", ProgressionConfig{System: Flat, Unit: unit}, "LWL", []int64{10, 10, 10, 10}},
+		{"martingale resets on a win", ProgressionConfig{System: Martingale, Unit: unit}, "LLLW", []int64{10, 20, 40, 80, 10}},
+		{"martingale push", ProgressionConfig{System: Martingale, Unit: unit}, "LPL", []int64{10, 20, 20, 40}},
+		{"steps", ProgressionConfig{System: Steps, Steps: []casino.Money{500, 1000, 2000}}, "LLWW", []int64{5, 10, 20, 10, 5}},
+		{"fibonacci", ProgressionConfig{System: Fibonacci, Unit: unit}, "LLLLW", []int64{10, 10, 20, 30, 50, 20}},
+		{"dalembert", ProgressionConfig{System: DAlembert, Unit: unit}, "LLW", []int64{10, 20, 30, 20}},
+		{"paroli", ProgressionConfig{System: Paroli, Unit: unit}, "WWL", []int64{10, 20, 40, 10}},
+		{"labouchere", ProgressionConfig{System: Labouchere, Unit: unit}, "LWWW", []int64{50, 60, 60, 30, 50}},
+		{"oscars grind", ProgressionConfig{System: OscarsGrind, Unit: unit}, "LLWW", []int64{10, 10, 10, 20, 10}},
+
+		// Reset on win sends a negative progression back to its first step
+		{"fibonacci reset on win", ProgressionConfig{System: Fibonacci, Unit: unit, ResetOnWin: true}, "LLLW", []int64{10, 10, 20, 30, 10}},
+		{"steps reset on win", ProgressionConfig{System: Steps, Steps: []casino.Money{500, 1000, 2000}, ResetOnWin: true}, "LLW", []int64{5, 10, 20, 5}},
+		{"paroli ignores reset on win", ProgressionConfig{System: Paroli, Unit: unit, ResetOnWin: true}, "WW", []int64{10, 20, 40}},
+
+		// End rules after the last step
+		{"restart", ProgressionConfig{System: Martingale, Unit: unit, MaxSteps: 3, AtEnd: RestartOnEnd}, "LLL", []int64{10, 20, 40, 10}},
+		{"cap", ProgressionConfig{System: Martingale, Unit: unit, MaxSteps: 3, AtEnd: CapAtEnd}, "LLLL", []int64{10, 20, 40, 40, 40}},
+		{"stop", ProgressionConfig{System: Martingale, Unit: unit, MaxSteps: 3, AtEnd: StopOnEnd}, "LLL", []int64{10, 20, 40, 0}},
+		{"paroli cap", ProgressionConfig{System: Paroli, Unit: unit, MaxSteps: 2, AtEnd: CapAtEnd}, "WWW", []int64{10, 20, 20, 20}},
+		{"labouchere stop", ProgressionConfig{System: Labouchere, Unit: unit, AtEnd: StopOnEnd}, "WW", []int64{50, 50, 0}},
+		{"oscars grind cap", ProgressionConfig{System: OscarsGrind, Unit: unit, MaxSteps: 1, AtEnd: CapAtEnd}, "LLLW", []int64{10, 10, 10, 10, 10}},
+	}
+	for _, c := range cases {
+		p, err := NewProgression(c.cfg)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		got := stakes(p, c.results)
+		if len(got) != len(c.want) {
+			t.Errorf("%s: stakes %v, want %v", c.name, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: stakes %v, want %v", c.name, got, c.want)
+				break
+			}
+		}
+
+		// Reset starts the progression over
+		p.Reset()
+		if first := stakes(p, ""); first[0] != c.want[0] {
+			t.Errorf("%s: stake after a reset = %d, want %d", c.name, first[0], c.want[0])
+		}
+	}
+}
+
+func TestProgressionErrors(t *testing.T) {
+	for _, cfg := range []ProgressionConfig{
+		{System: "bogus", Unit: casino.Dollars(10)},
+		{System: Martingale},
+		{System: Steps},
+		{System: Flat, Unit: casino.Dollars(10), AtEnd: "never"},
+		{System: Kelly, Sizing: casino.Kelly{Fraction: 2, Variance: 1}},
+	} {
+		if _, err := NewProgression(cfg); err == nil {
+			t.Errorf("NewProgression(%+v) should fail", cfg)
+		}
+	}
+}
+
+func TestRoundedProgression(t *testing.T) {
+	chips := casino.Chips{Denominations: []casino.Money{casino.Dollars(5), casino.Dollars(25)}}
+	p, err := NewProgression(ProgressionConfig{System: Martingale, Unit: casino.Dollars(7), Chips: chips})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stakes(p, "L"); got[0] != 10 || got[1] != 15 {
+		t.Errorf("stakes rounded up to $5 chips = %v, want [10 15]", got)
+	}
+}
@@ -0,0 +1,71 @@
+package roulette
+
+import (
+	"math/rand"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// Seeder is a spin source that can replay the same spins from a seed
+type Seeder interface {
+	SpinSource
+	// WithRand returns a copy of the source drawing from the generator
+	WithRand(rng *rand.Rand) SpinSource
+}
+
+// Seeded returns the source drawing its spins from a generator seeded with
+// seed, or the source itself when it cannot be seeded, such as a spin log
+func Seeded(source SpinSource, seed int64) SpinSource {
+	if s, ok := source.(Seeder); ok {
+		return s.WithRand(casino.NewRand(seed))
+	}
+	return source
+}
+
+// random draws from a generator of its own, or from the shared one when it has none
+type random struct {
+	rng *rand.Rand
+}
+
+// generated is a spin source that lends its generator to the other draws
+// of a round, such as the numbers Lightning strikes
+type generated interface {
+	generator() random
+}
+
+// generatorOf returns the generator of a source, or the shared one when
+// the source has none, such as a spin log
+func generatorOf(source SpinSource) random {
+	if g, ok := source.(generated); ok {
+		return g.generator()
+	}
+	return random{}
+}
+
+func (r random) Intn(n int) int {
+	if r.rng == nil {
+		return rand.Intn(n)
+	}
+	return r.rng.Intn(n)
+}
+
+func (r random) Float64() float64 {
+	if r.rng == nil {
+		return rand.Float64()
+	}
+	return r.rng.Float64()
+}
+
+func (r random) NormFloat64() float64 {
+	if r.rng == nil {
+		return rand.NormFloat64()
+	}
+	return r.rng.NormFloat64()
+}
+
+func (r random) Perm(n int) []int {
+	if r.rng == nil {
+		return rand.Perm(n)
+	}
+	return r.rng.Perm(n)
+}
@@ -0,0 +1,77 @@
+package roulette
+
+import (
+	casino "github.com/BryceWayne/casino"
+)
+
+// Result represents the result of a simulated session
+type Result struct {
+	Balance     casino.Money
+	SpinCount   int
+	Exhausted   bool    // The spin log ran out before the session ended
+	Capped      bool    // The session reached the spin cap
+	MaxDrawdown float64 // Largest fall of the balance from its peak, as a share of the peak
+}
+
+// exhausted reports whether a spin log ran out during the round
+func exhausted(round Round) bool {
+	for _, ball := range round.Balls {
+		if ball == NoSpin {
+			return true
+		}
+	}
+	return false
+}
+
+// Run a single simulation and return the result
+func RunSimulation(variant Variant, source SpinSource, initialBalance casino.Money, bets []Bet, progressions []ProgressionConfig, limits TableLimits, atLimit LimitRule, profitGoal casino.Money, stopLoss casino.Money, maxSpins int) (Result, error) {
+	// Each bet carries its own progression
+	wagers := make([]*Wager, len(bets))
+	for i, bet := range bets {
+		progression, err := NewProgression(progressions[i])
+		if err != nil {
+			return Result{}, err
+		}
+		wagers[i] = &Wager{Bet: bet, Progression: progression, AtLimit: atLimit}
+	}
+
+	balance := initialBalance
+	spinCount := 0
+	ranOut := false
+	drawdown := casino.Drawdown{Peak: initialBalance}
+
+	for balance > 0 && balance < initialBalance+profitGoal && (maxSpins == 0 || spinCount < maxSpins) {
+		// Quit the game if a progression stops, the table limits cannot be met, the bets exceed the available balance or the stop loss is hit
+		SetBalance(wagers, balance)
+		quit := !limits.Place(wagers) || balance < stopLoss
+		var staked casino.Money
+		for _, wager := range wagers {
+			staked += wager.Amount
+		}
+		if quit || staked > balance {
+			break
+		}
+
+		round := variant.Spin(source)
+		if exhausted(round) {
+			ranOut = true
+			break
+		}
+		spinCount++
+
+		for _, wager := range wagers {
+			settlement := variant.Settle(wager.Bet, round)
+			balance += settlement.Net
+			wager.Progression.Record(settlement.Net)
+		}
+		drawdown.Record(balance)
+	}
+
+	return Result{
+		Balance:     balance,
+		SpinCount:   spinCount,
+		Exhausted:   ranOut,
+		Capped:      maxSpins > 0 && spinCount >= maxSpins,
+		MaxDrawdown: drawdown.Max,
+	}, nil
+}
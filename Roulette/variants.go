@@ -0,0 +1,330 @@
+package roulette
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// Round is the result of one spin of a variant
+type Round struct {
+	Balls       []int       // Winning pocket of each ball
+	Multipliers map[int]int // Straight-up multipliers drawn for the round, by pocket
+}
+
+// Variant is a roulette game: its wheel, how a round is spun and how bets are paid
+type Variant interface {
+	// Name returns the name of the variant
+	Name() string
+	// Pockets returns the number of pockets on the wheel
+	Pockets() int
+	// Top returns the highest number on the layout
+	Top() int
+	// American reports whether the wheel has a double zero
+	American() bool
+	// Spin plays a round, drawing each ball from the source
+	Spin(source SpinSource) Round
+	// Settle pays or collects a bet for the round
+	Settle(bet Bet, round Round) casino.Settlement
+	// HouseEdge returns the exact expected loss per unit staked on the bet
+	HouseEdge(bet Bet) float64
+}
+
+// NewVariant builds a variant by name: european, la_partage, american,
+// mini, double_ball or lightning
+func NewVariant(name string) (Variant, error) {
+	switch name {
+	case "european":
+		return Classic{European: true}, nil
+	case "la_partage":
+		return Classic{European: true, LaPartage: true}, nil
+	case "american":
+		return Classic{}, nil
+	case "mini":
+		return Mini{}, nil
+	case "double_ball":
+		return DoubleBall{Classic{European: true}}, nil
+	case "lightning":
+		return DefaultLightning(), nil
+	}
+	return nil, fmt.Errorf("unknown roulette variant %q", name)
+}
+
+// Payout returns the classic payout for a bet covering count numbers of a
+// layout numbered 1 to top: the fair payout ignoring the zeros, rounded down
+func Payout(count, top int) int {
+	return top/count - 1
+}
+
+// StandardBets returns one bet of each kind offered by the variant
+func StandardBets(v Variant) []Bet {
+	var bets []Bet
+	inside := []Bet{
+		{Kind: Straight, Numbers: []int{1}},
+		{Kind: Split, Numbers: []int{1, 2}},
+		{Kind: Street, Numbers: []int{1, 2, 3}},
+		{Kind: Corner, Numbers: []int{1, 2, 4, 5}},
+	}
+	if v.American() {
+		inside = append(inside, Bet{Kind: Basket, Numbers: []int{0, 1, 2, 3, DoubleZero}})
+	}
+	if v.Top() >= 36 {
+		inside = append(inside, Bet{Kind: SixLine, Numbers: Range(1, 6)})
+	}
+	for _, bet := range inside {
+		bet.Amount = casino.Dollar
+		bets = append(bets, bet)
+	}
+
+	outside := []Kind{Red, Black, Odd, Even, Low, High}
+	if v.Top() >= 36 {
+		outside = append([]Kind{Dozen, Column}, outside...)
+	}
+	for _, kind := range outside {
+		numbers, _ := OutsideNumbers(kind, 1, v.Top())
+		bets = append(bets, Bet{Kind: kind, Numbers: numbers, Amount: casino.Dollar})
+	}
+	return bets
+}
+
+// spinBalls draws the given number of balls from the source
+func spinBalls(source SpinSource, balls int) []int {
+	pockets := make([]int, balls)
+	for i := range pockets {
+		pockets[i] = source.Spin()
+	}
+	return pockets
+}
+
+// edgeOverPockets averages a single ball settlement over a uniform wheel
+func edgeOverPockets(v Variant, bet Bet) float64 {
+	var net, staked casino.Money
+	for pocket := 0; pocket < v.Pockets(); pocket++ {
+		settlement := v.Settle(bet, Round{Balls: []int{pocket}})
+		net += settlement.Net
+		staked += settlement.Stake
+	}
+	return -float64(net) / float64(staked)
+}
+
+// evenMoney reports whether the bet pays 1:1 on the classic layout
+func evenMoney(bet Bet) bool {
+	switch bet.Kind {
+	case Red, Black, Odd, Even, Low, High:
+		return true
+	}
+	return false
+}
+
+// Classic is single zero European or double zero American roulette. With
+// la partage, even-money bets lose only half their stake when a zero comes up.
+type Classic struct {
+	European  bool
+	LaPartage bool
+}
+
+func (c Classic) Name() string {
+	switch {
+	case c.European && c.LaPartage:
+		return "European (la partage)"
+	case c.European:
+		return "European"
+	}
+	return "American"
+}
+
+func (c Classic) Pockets() int   { return Pockets(c.European) }
+func (c Classic) Top() int       { return 36 }
+func (c Classic) American() bool { return !c.European }
+
+func (c Classic) Spin(source SpinSource) Round {
+	return Round{Balls: spinBalls(source, 1)}
+}
+
+func (c Classic) Settle(bet Bet, round Round) casino.Settlement {
+	ball := round.Balls[0]
+	if bet.Covers(ball) {
+		return casino.Won(bet.Amount, bet.Amount*casino.Money(Payout(len(bet.Numbers), c.Top())))
+	}
+	if c.LaPartage && evenMoney(bet) && (ball == 0 || ball == DoubleZero) {
+		return casino.HalfLost(bet.Amount)
+	}
+	return casino.Lost(bet.Amount)
+}
+
+func (c Classic) HouseEdge(bet Bet) float64 { return edgeOverPockets(c, bet) }
+
+// Mini is roulette on a 13 pocket wheel with a zero and the numbers 1 to
+// 12, laid out like the first four rows of the classic layout
+type Mini struct{}
+
+// miniWheel spins the 13 pocket mini roulette wheel
+type miniWheel struct {
+	rng random
+}
+
+func (m miniWheel) Spin() int { return m.rng.Intn(13) }
+
+func (m miniWheel) WithRand(rng *rand.Rand) SpinSource { return miniWheel{random{rng}} }
+
+func (m miniWheel) generator() random { return m.rng }
+
+// MiniWheel returns a fair mini roulette wheel
+func MiniWheel() SpinSource { return miniWheel{} }
+
+// VariantWheel returns a fair wheel with the pockets of a variant
+func VariantWheel(v Variant) SpinSource {
+	if v.Pockets() == 13 {
+		return MiniWheel()
+	}
+	wheel, _ := NewWheel(!v.American(), nil)
+	return wheel
+}
+
+func (Mini) Name() string   { return "Mini" }
+func (Mini) Pockets() int   { return 13 }
+func (Mini) Top() int       { return 12 }
+func (Mini) American() bool { return false }
+
+func (m Mini) Spin(source SpinSource) Round {
+	return Round{Balls: spinBalls(source, 1)}
+}
+
+func (m Mini) Settle(bet Bet, round Round) casino.Settlement {
+	if bet.Covers(round.Balls[0]) {
+		return casino.Won(bet.Amount, bet.Amount*casino.Money(Payout(len(bet.Numbers), m.Top())))
+	}
+	return casino.Lost(bet.Amount)
+}
+
+func (m Mini) HouseEdge(bet Bet) float64 { return edgeOverPockets(m, bet) }
+
+// DoubleBall launches two independent balls on a classic wheel; every bet
+// stands on each ball separately, so it stakes its amount twice and the two
+// settlements are combined
+type DoubleBall struct {
+	Classic
+}
+
+func (d DoubleBall) Name() string { return "Double Ball (" + d.Classic.Name() + ")" }
+
+func (d DoubleBall) Spin(source SpinSource) Round {
+	return Round{Balls: spinBalls(source, 2)}
+}
+
+func (d DoubleBall) Settle(bet Bet, round Round) casino.Settlement {
+	settlements := make([]casino.Settlement, len(round.Balls))
+	for i, ball := range round.Balls {
+		settlements[i] = d.Classic.Settle(bet, Round{Balls: []int{ball}})
+	}
+	return casino.Combine(settlements...)
+}
+
+func (d DoubleBall) HouseEdge(bet Bet) float64 {
+	var net, staked casino.Money
+	pockets := d.Pockets()
+	for first := 0; first < pockets; first++ {
+		for second := 0; second < pockets; second++ {
+			settlement := d.Settle(bet, Round{Balls: []int{first, second}})
+			net += settlement.Net
+			staked += settlement.Stake
+		}
+	}
+	return -float64(net) / float64(staked)
+}
+
+// Weighted is a value drawn with a relative weight
+type Weighted struct {
+	Value  int
+	Weight float64
+}
+
+// Lightning is single zero roulette where each round strikes between one
+// and five random numbers with a multiplier. A straight-up bet on a struck
+// number pays the multiplier instead of the reduced base payout.
+type Lightning struct {
+	Classic
+	StraightPayout int        // Base straight-up payout
+	Strikes        []Weighted // Distribution of how many numbers are struck
+	Multipliers    []Weighted // Distribution of each struck number's multiplier
+}
+
+// DefaultLightning returns lightning roulette with a 29:1 base straight-up payout
+func DefaultLightning() Lightning {
+	return Lightning{
+		Classic:        Classic{European: true},
+		StraightPayout: 29,
+		Strikes:        []Weighted{{1, 0.25}, {2, 0.30}, {3, 0.25}, {4, 0.12}, {5, 0.08}},
+		Multipliers: []Weighted{
+			{50, 0.40}, {100, 0.27}, {150, 0.13}, {200, 0.08},
+			{250, 0.05}, {300, 0.04}, {400, 0.02}, {500, 0.01},
+		},
+	}
+}
+
+// draw picks a value from a weighted distribution
+func draw(rng random, values []Weighted) int {
+	total := 0.0
+	for _, w := range values {
+		total += w.Weight
+	}
+	r := rng.Float64() * total
+	for _, w := range values {
+		if r < w.Weight {
+			return w.Value
+		}
+		r -= w.Weight
+	}
+	return values[len(values)-1].Value
+}
+
+// mean returns the expected value of a weighted distribution
+func mean(values []Weighted) float64 {
+	total, sum := 0.0, 0.0
+	for _, w := range values {
+		total += w.Weight
+		sum += w.Weight * float64(w.Value)
+	}
+	return sum / total
+}
+
+func (l Lightning) Name() string { return "Lightning" }
+
+// Spin strikes the numbers and their multipliers with the source's
+// generator, so a seeded source replays the same rounds
+func (l Lightning) Spin(source SpinSource) Round {
+	round := Round{Balls: spinBalls(source, 1), Multipliers: map[int]int{}}
+	rng := generatorOf(source)
+	strikes := draw(rng, l.Strikes)
+	pockets := rng.Perm(l.Pockets())[:strikes]
+	sort.Ints(pockets)
+	for _, pocket := range pockets {
+		round.Multipliers[pocket] = draw(rng, l.Multipliers)
+	}
+	return round
+}
+
+func (l Lightning) Settle(bet Bet, round Round) casino.Settlement {
+	ball := round.Balls[0]
+	if bet.Kind != Straight || !bet.Covers(ball) {
+		return l.Classic.Settle(bet, round)
+	}
+	if multiplier, ok := round.Multipliers[ball]; ok {
+		return casino.Won(bet.Amount, bet.Amount*casino.Money(multiplier))
+	}
+	return casino.Won(bet.Amount, bet.Amount*casino.Money(l.StraightPayout))
+}
+
+func (l Lightning) HouseEdge(bet Bet) float64 {
+	if bet.Kind != Straight {
+		return l.Classic.HouseEdge(bet)
+	}
+
+	// Every number is equally likely to be struck, whatever the ball does
+	struck := mean(l.Strikes) / float64(l.Pockets())
+	win := (1-struck)*float64(l.StraightPayout) + struck*mean(l.Multipliers)
+	hit := 1 / float64(l.Pockets())
+	return -(hit*win - (1 - hit))
+}
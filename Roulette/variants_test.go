@@ -0,0 +1,150 @@
+package roulette
+
+import (
+	"reflect"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// winningPocket returns a pocket covered by the bet
+func winningPocket(bet Bet) int {
+	return bet.Numbers[len(bet.Numbers)-1]
+}
+
+// losingPocket returns a non-zero pocket of the layout the bet misses
+func losingPocket(bet Bet, top int) int {
+	for pocket := 1; pocket <= top; pocket++ {
+		if !bet.Covers(pocket) {
+			return pocket
+		}
+	}
+	return 0
+}
+
+func TestClassicPayouts(t *testing.T) {
+	payouts := map[Kind]int{
+		Straight: 35, Split: 17, Street: 11, Corner: 8, Basket: 6, SixLine: 5,
+		Dozen: 2, Column: 2, Red: 1, Black: 1, Odd: 1, Even: 1, Low: 1, High: 1,
+	}
+	for _, table := range []Classic{{European: true}, {}} {
+		for _, bet := range StandardBets(table) {
+			bet.Amount = casino.Dollars(10)
+			net := bet.Amount * casino.Money(payouts[bet.Kind])
+			won := table.Settle(bet, Round{Balls: []int{winningPocket(bet)}})
+			want := casino.Settlement{Outcome: casino.Win, Stake: bet.Amount, Net: net, Return: bet.Amount + net}
+			if won != want {
+				t.Errorf("%s %s win: got %+v, want %+v", table.Name(), bet, won, want)
+			}
+			lost := table.Settle(bet, Round{Balls: []int{losingPocket(bet, table.Top())}})
+			if lost != casino.Lost(bet.Amount) {
+				t.Errorf("%s %s loss: got %+v", table.Name(), bet, lost)
+			}
+		}
+	}
+}
+
+func TestLaPartage(t *testing.T) {
+	table := Classic{European: true, LaPartage: true}
+	for _, bet := range StandardBets(table) {
+		bet.Amount = casino.Dollars(10)
+		got := table.Settle(bet, Round{Balls: []int{0}})
+		want := casino.Lost(bet.Amount)
+		if evenMoney(bet) {
+			want = casino.Settlement{Outcome: casino.HalfLose, Stake: bet.Amount, Net: -casino.Dollars(5), Return: casino.Dollars(5)}
+		}
+		if bet.Covers(0) {
+			continue
+		}
+		if got != want {
+			t.Errorf("%s on zero: got %+v, want %+v", bet, got, want)
+		}
+	}
+}
+
+func TestMiniPayouts(t *testing.T) {
+	payouts := map[Kind]int{Straight: 11, Split: 5, Street: 3, Corner: 2, Red: 1, Black: 1, Odd: 1, Even: 1, Low: 1, High: 1}
+	table := Mini{}
+	for _, bet := range StandardBets(table) {
+		got := table.Settle(bet, Round{Balls: []int{winningPocket(bet)}})
+		if got.Net != casino.Money(payouts[bet.Kind])*bet.Amount || got.Return != got.Net+got.Stake {
+			t.Errorf("%s: got %+v, want %d:1", bet, got, payouts[bet.Kind])
+		}
+	}
+}
+
+func TestDoubleBallPayouts(t *testing.T) {
+	table := DoubleBall{Classic{European: true}}
+	bet := Bet{Kind: Dozen, Numbers: Range(25, 36), Amount: 10}
+	cases := []struct {
+		balls   []int
+		outcome casino.Outcome
+		net     casino.Money
+	}{
+		{[]int{30, 31}, casino.Win, 40},
+		{[]int{30, 1}, casino.Win, 10},
+		{[]int{1, 2}, casino.Lose, -20},
+	}
+	for _, c := range cases {
+		got := table.Settle(bet, Round{Balls: c.balls})
+		if got.Outcome != c.outcome || got.Net != c.net || got.Stake != 20 || got.Return != 20+c.net {
+			t.Errorf("balls %v: got %+v, want %s net %d", c.balls, got, c.outcome, c.net)
+		}
+	}
+
+	// One ball on an even-money bet and one against it push
+	numbers, _ := OutsideNumbers(Red, 1, 36)
+	red := Bet{Kind: Red, Numbers: numbers, Amount: 10}
+	if got := table.Settle(red, Round{Balls: []int{1, 2}}); got.Outcome != casino.Push || got.Net != 0 {
+		t.Errorf("split result: got %+v, want a push", got)
+	}
+}
+
+func TestLightningPayouts(t *testing.T) {
+	table := DefaultLightning()
+	bet := Bet{Kind: Straight, Numbers: []int{17}, Amount: 10}
+	if got := table.Settle(bet, Round{Balls: []int{17}}); got.Net != 290 {
+		t.Errorf("plain straight up: got %+v, want 29:1", got)
+	}
+	if got := table.Settle(bet, Round{Balls: []int{17}, Multipliers: map[int]int{17: 200}}); got.Net != 2000 {
+		t.Errorf("struck straight up: got %+v, want 200:1", got)
+	}
+	dozen := Bet{Kind: Dozen, Numbers: Range(13, 24), Amount: 10}
+	if got := table.Settle(dozen, Round{Balls: []int{17}, Multipliers: map[int]int{17: 200}}); got.Net != 20 {
+		t.Errorf("dozen: got %+v, want 2:1", got)
+	}
+}
+
+func TestLightningSeeded(t *testing.T) {
+	table := DefaultLightning()
+	wheel, _ := NewWheel(true, nil)
+	rounds := func(seed int64) []Round {
+		source := Seeded(wheel, seed)
+		played := make([]Round, 50)
+		for i := range played {
+			played[i] = table.Spin(source)
+		}
+		return played
+	}
+	a, b := rounds(42), rounds(42)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("seed 42 struck different numbers or multipliers")
+	}
+	if reflect.DeepEqual(a, rounds(43)) {
+		t.Error("seeds 42 and 43 played the same rounds")
+	}
+}
+
+func TestBetOdds(t *testing.T) {
+	table := Classic{European: true}
+	wheel, _ := NewWheel(true, nil)
+	bet := Bet{Kind: Dozen, Numbers: Range(25, 36)}
+	edge, variance := BetOdds(table, bet, wheel.Probabilities())
+	if want := -table.HouseEdge(bet); edge < want-1e-12 || edge > want+1e-12 {
+		t.Errorf("edge: got %g, want %g", edge, want)
+	}
+	p := 12.0 / 37
+	if want := p*4 + (1 - p) - edge*edge; variance < want-1e-12 || variance > want+1e-12 {
+		t.Errorf("variance: got %g, want %g", variance, want)
+	}
+}
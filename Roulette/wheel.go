@@ -0,0 +1,191 @@
+// Package roulette holds the wheel, bet and strategy logic shared by the
+// Roulette commands.
+package roulette
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DoubleZero is the pocket number used for 00 on an American wheel
+const DoubleZero = 37
+
+// europeanOrder is the order of the pockets around a single zero wheel
+var europeanOrder = []int{0, 32, 15, 19, 4, 21, 2, 25, 17, 34, 6, 27, 13, 36, 11, 30, 8, 23, 10, 5, 24, 16, 33, 1, 20, 14, 31, 9, 22, 18, 29, 7, 28, 12, 35, 3, 26}
+
+// americanOrder is the order of the pockets around a double zero wheel
+var americanOrder = []int{0, 28, 9, 26, 30, 11, 7, 20, 32, 17, 5, 22, 34, 15, 3, 24, 36, 13, 1, DoubleZero, 27, 10, 25, 29, 12, 8, 19, 31, 18, 6, 21, 33, 16, 4, 23, 35, 14, 2}
+
+// Pockets returns the number of pockets on the wheel
+func Pockets(european bool) int {
+	if european {
+		return 37 // 0-36
+	}
+	return 38 // 0-36 and 00
+}
+
+// WheelOrder returns the pockets in the order they sit around the wheel
+func WheelOrder(european bool) []int {
+	if european {
+		return europeanOrder
+	}
+	return americanOrder
+}
+
+// Range returns the numbers from low to high inclusive
+func Range(low, high int) []int {
+	numbers := make([]int, 0, high-low+1)
+	for n := low; n <= high; n++ {
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
+
+// PocketName returns how a pocket is written, with 37 as "00"
+func PocketName(pocket int) string {
+	if pocket == DoubleZero {
+		return "00"
+	}
+	return strconv.Itoa(pocket)
+}
+
+// ParsePocket reads a pocket written as a number or "00"
+func ParsePocket(s string, european bool) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "00" {
+		if european {
+			return 0, fmt.Errorf("there is no 00 on a European wheel")
+		}
+		return DoubleZero, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 36 {
+		return 0, fmt.Errorf("invalid pocket %q", s)
+	}
+	return n, nil
+}
+
+// ReadSpins reads a spin log with one pocket per line, skipping blank lines
+// and lines starting with #
+func ReadSpins(r io.Reader, european bool) ([]int, error) {
+	var spins []int
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		pocket, err := ParsePocket(text, european)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		spins = append(spins, pocket)
+	}
+	return spins, scanner.Err()
+}
+
+// Wheel spins a roulette wheel, uniformly unless pocket weights are given
+type Wheel struct {
+	European   bool
+	Weights    []float64 // Relative weight of each pocket, nil for a fair wheel
+	cumulative []float64
+	rng        random
+}
+
+// NewWheel builds a wheel; weights may be nil for a fair wheel
+func NewWheel(european bool, weights []float64) (*Wheel, error) {
+	wheel := &Wheel{European: european}
+	if weights == nil {
+		return wheel, nil
+	}
+	if len(weights) != Pockets(european) {
+		return nil, fmt.Errorf("wheel has %d pockets but %d weights were given", Pockets(european), len(weights))
+	}
+
+	total := 0.0
+	wheel.cumulative = make([]float64, len(weights))
+	for i, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("pocket %s has a negative weight", PocketName(i))
+		}
+		total += w
+		wheel.cumulative[i] = total
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("wheel weights are all zero")
+	}
+	for i := range wheel.cumulative {
+		wheel.cumulative[i] /= total
+	}
+	wheel.Weights = weights
+	return wheel, nil
+}
+
+// Spin returns the pocket the ball lands in
+func (w *Wheel) Spin() int {
+	if w.cumulative == nil {
+		return w.rng.Intn(Pockets(w.European))
+	}
+	pocket := sort.SearchFloat64s(w.cumulative, w.rng.Float64())
+	if pocket >= len(w.cumulative) {
+		pocket = len(w.cumulative) - 1
+	}
+	return pocket
+}
+
+// WithRand returns a copy of the wheel drawing from the generator
+func (w *Wheel) WithRand(rng *rand.Rand) SpinSource {
+	seeded := *w
+	seeded.rng = random{rng}
+	return &seeded
+}
+
+func (w *Wheel) generator() random { return w.rng }
+
+// Probabilities returns the chance of each pocket coming up
+func (w *Wheel) Probabilities() []float64 {
+	pockets := Pockets(w.European)
+	probabilities := make([]float64, pockets)
+	previous := 0.0
+	for i := range probabilities {
+		if w.cumulative == nil {
+			probabilities[i] = 1 / float64(pockets)
+			continue
+		}
+		probabilities[i] = w.cumulative[i] - previous
+		previous = w.cumulative[i]
+	}
+	return probabilities
+}
+
+// ParseBias builds pocket weights from a list such as "17:1.5,32:1.2";
+// pockets that are not listed keep a weight of 1
+func ParseBias(spec string, european bool) ([]float64, error) {
+	weights := make([]float64, Pockets(european))
+	for i := range weights {
+		weights[i] = 1
+	}
+	for _, field := range strings.Split(spec, ",") {
+		parts := strings.Split(field, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid bias %q, want pocket:weight", field)
+		}
+		pocket, err := ParsePocket(parts[0], european)
+		if err != nil {
+			return nil, err
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q", parts[1])
+		}
+		weights[pocket] = weight
+	}
+	return weights, nil
+}
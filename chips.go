@@ -0,0 +1,105 @@
+package casino
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChipRule decides what happens to a bet that cannot be made from the chips
+type ChipRule string
+
+const (
+	ChipUp     ChipRule = "up"     // Round the bet up to the next legal amount
+	ChipDown   ChipRule = "down"   // Round the bet down, refusing it if nothing is left
+	ChipRefuse ChipRule = "refuse" // Refuse the bet
+)
+
+// ParseChipRule checks a chip rounding rule given on the command line
+func ParseChipRule(s string) (ChipRule, error) {
+	switch rule := ChipRule(s); rule {
+	case ChipUp, ChipDown, ChipRefuse:
+		return rule, nil
+	}
+	return "", fmt.Errorf("unknown chip rule %q", s)
+}
+
+// Chips are the denominations a table plays with. Every bet must be a
+// multiple of the minimum unit, which defaults to the smallest chip. The
+// zero value accepts any amount.
+type Chips struct {
+	Denominations []Money
+	Unit          Money
+}
+
+// ParseChips parses a comma separated list of chip values in dollars
+func ParseChips(s string) (Chips, error) {
+	var chips Chips
+	for _, field := range strings.Split(s, ",") {
+		value, err := ParseMoney(field)
+		if err != nil || value <= 0 {
+			return Chips{}, fmt.Errorf("invalid chip %q", field)
+		}
+		chips.Denominations = append(chips.Denominations, value)
+	}
+	sort.Slice(chips.Denominations, func(i, j int) bool { return chips.Denominations[i] < chips.Denominations[j] })
+	return chips, chips.Validate()
+}
+
+// Validate checks that every multiple of the unit can be made from the chips
+func (c Chips) Validate() error {
+	if len(c.Denominations) == 0 {
+		return errors.New("no chip denominations")
+	}
+	if c.Unit < 0 || c.unit()%c.smallest() != 0 {
+		return fmt.Errorf("minimum unit %s is not a multiple of the smallest chip %s", c.Unit, c.smallest())
+	}
+	return nil
+}
+
+// smallest returns the smallest chip
+func (c Chips) smallest() Money {
+	smallest := c.Denominations[0]
+	for _, chip := range c.Denominations {
+		if chip < smallest {
+			smallest = chip
+		}
+	}
+	return smallest
+}
+
+// unit returns the minimum unit of a bet, no restriction when there are no chips
+func (c Chips) unit() Money {
+	if c.Unit > 0 || len(c.Denominations) == 0 {
+		return c.Unit
+	}
+	return c.smallest()
+}
+
+// Enabled reports whether the chips restrict bets at all
+func (c Chips) Enabled() bool {
+	return len(c.Denominations) > 0 || c.Unit > 0
+}
+
+// Legal reports whether the amount can be bet with the chips
+func (c Chips) Legal(amount Money) bool {
+	unit := c.unit()
+	return amount > 0 && (unit <= 0 || amount%unit == 0)
+}
+
+// Round applies the rule to an amount, returning the amount to bet and
+// false when the bet is refused
+func (c Chips) Round(amount Money, rule ChipRule) (Money, bool) {
+	if c.Legal(amount) {
+		return amount, true
+	}
+	switch rule {
+	case ChipUp:
+		return amount.Ceil(c.unit()), amount > 0
+	case ChipDown:
+		rounded := amount.Floor(c.unit())
+		return rounded, rounded > 0
+	}
+	return 0, false
+}
@@ -0,0 +1,38 @@
+package casino
+
+import "testing"
+
+func TestChipRounding(t *testing.T) {
+	chips, err := ParseChips("25,5,100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		amount Money
+		rule   ChipRule
+		want   Money
+		ok     bool
+	}{
+		{Dollars(25), ChipRefuse, Dollars(25), true},
+		{3750, ChipUp, Dollars(40), true},
+		{3750, ChipDown, Dollars(35), true},
+		{3750, ChipRefuse, 0, false},
+		{Dollars(3), ChipDown, 0, false},
+		{Dollars(3), ChipUp, Dollars(5), true},
+	}
+	for _, c := range cases {
+		got, ok := chips.Round(c.amount, c.rule)
+		if got != c.want || ok != c.ok {
+			t.Errorf("%s rounded %s: got %s, %v, want %s, %v", c.amount, c.rule, got, ok, c.want, c.ok)
+		}
+	}
+
+	chips.Unit = Dollars(25)
+	if chips.Legal(Dollars(30)) || !chips.Legal(Dollars(50)) {
+		t.Error("bets must be multiples of the minimum unit")
+	}
+	chips.Unit = Dollars(7)
+	if chips.Validate() == nil {
+		t.Error("a unit the chips cannot make should be rejected")
+	}
+}
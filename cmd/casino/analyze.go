@@ -0,0 +1,293 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	roulette "github.com/BryceWayne/casino/Roulette"
+)
+
+// Build the chain for one of the simulated strategies
+func strategyChain(strategy string, unitBet casino.Money) (roulette.Chain, error) {
+	switch strategy {
+	case "fib":
+		return roulette.Chain{
+			Bets:       []roulette.ChainBet{{Numbers: roulette.Range(25, 36), Payout: 2}},
+			Steps:      []casino.Money{unitBet, unitBet, 2 * unitBet, 3 * unitBet, 5 * unitBet, 8 * unitBet, 13 * unitBet},
+			Balance:    casino.Dollars(25_000),
+			ProfitGoal: casino.Dollars(5_000),
+		}, nil
+	case "martingale":
+		return roulette.Chain{
+			Bets: []roulette.ChainBet{
+				{Numbers: roulette.Range(19, 36), Payout: 1},
+				{Numbers: roulette.Range(25, 36), Payout: 2},
+			},
+			Steps:      []casino.Money{casino.Dollars(25), casino.Dollars(50), casino.Dollars(150), casino.Dollars(450), casino.Dollars(850)},
+			Balance:    casino.Dollars(10_000),
+			ProfitGoal: casino.Dollars(1_000),
+		}, nil
+	}
+	return roulette.Chain{}, fmt.Errorf("unknown strategy %q", strategy)
+}
+
+// analyzeRuin solves the exact win rate and expected length of a strategy
+func analyzeRuin(args []string) int {
+	fs := newFlagSet("analyze ruin", "Solve the exact risk of ruin of a step progression")
+
+	// Define command-line arguments
+	config := fs.String("config", "", "Scenario file (YAML or JSON) with the settings; flags override it")
+	strategy := fs.String("strategy", "fib", "Strategy to analyze (fib or martingale)")
+	unitBet := casino.MoneyFlag(fs, "bet", casino.Dollars(100), "Unit bet amount (fib)")
+	steps := fs.String("steps", "", "Comma separated bet amounts overriding the strategy's")
+	initialBalance := casino.MoneyFlag(fs, "balance", 0, "Initial balance (default: the strategy's)")
+	profitGoal := casino.MoneyFlag(fs, "profit", 0, "Profit goal (default: the strategy's)")
+	stopLoss := casino.MoneyFlag(fs, "stoploss", 0, "Stop loss")
+	european := fs.Bool("european", false, "Use European wheel (single 0)")
+	tolerance := fs.Float64("tolerance", 1e-12, "Convergence tolerance")
+	maxIterations := fs.Int("iterations", 1_000_000, "Maximum number of iterations")
+
+	if code, ok := parseFlags(fs, args, config, "roulette"); !ok {
+		return code
+	}
+
+	chain, err := strategyChain(*strategy, *unitBet)
+	if err != nil {
+		return usageError(err)
+	}
+	if *steps != "" {
+		if chain.Steps, err = roulette.ParseSteps(*steps); err != nil {
+			return usageError(err)
+		}
+	}
+	if *initialBalance > 0 {
+		chain.Balance = *initialBalance
+	}
+	if *profitGoal > 0 {
+		chain.ProfitGoal = *profitGoal
+	}
+	chain.StopLoss = *stopLoss
+	chain.Pockets = roulette.Pockets(*european)
+
+	result, err := chain.Solve(*tolerance, *maxIterations)
+	if err != nil {
+		return fail(err)
+	}
+
+	fmt.Printf("Exact result for %s (%d states, %d iterations):\n", *strategy, result.States, result.Iterations)
+	fmt.Printf("Win rate: %.4f%%\n", result.SuccessProbability*100)
+	fmt.Printf("Lose rate: %.4f%%\n", (1-result.SuccessProbability)*100)
+	fmt.Printf("Expected number of spins: %.2f\n", result.ExpectedSpins)
+	return exitOK
+}
+
+// Load the spin log, or spin the source when no log is given
+func loadSpins(path string, european bool, source roulette.SpinSource, numSpins int) ([]int, error) {
+	if path == "" {
+		spins := make([]int, numSpins)
+		for i := range spins {
+			spins[i] = source.Spin()
+		}
+		return spins, nil
+	}
+	return roulette.LoadSpinFile(path, european)
+}
+
+// Join the pocket names of a sector
+func sectorName(pockets []int) string {
+	names := make([]string, len(pockets))
+	for i, pocket := range pockets {
+		names[i] = roulette.PocketName(pocket)
+	}
+	return strings.Join(names, "-")
+}
+
+// analyzeBias tests spins for a biased wheel and simulates exploiting it
+func analyzeBias(args []string) int {
+	fs := newFlagSet("analyze bias", "Test a spin log for a biased wheel and how to exploit it")
+
+	// Define command-line arguments
+	config := fs.String("config", "", "Scenario file (YAML or JSON) with the settings; flags override it")
+	spinFile := fs.String("spinfile", "", "Spin log to analyze, one number per line (default: simulate spins)")
+	european := fs.Bool("european", false, "Use European wheel (single 0)")
+	bias := fs.String("bias", "", "Biased pocket weights such as 17:1.5,32:1.2 used for simulated spins and detection estimates")
+	physics := fs.String("physics", "", "Simulate spins with the ball and rotor physics model, \"default\" or settings such as ball=2.2,tilt=0.02,signature")
+	numSpins := fs.Int("n", 10_000, "Number of spins to simulate when no log is given")
+	sectorWidth := fs.Int("sector", 5, "Number of neighbouring pockets in a sector")
+	top := fs.Int("top", 5, "Number of pockets and sectors to list")
+	alpha := fs.Float64("alpha", 0.01, "Significance level")
+	power := fs.Float64("power", 0.9, "Power for the detection estimate")
+	exploitSims := fs.Int("exploit", 0, "Number of bias-exploiting sessions to simulate")
+	learnSpins := fs.Int("learn", 2_000, "Spins watched before betting in an exploiting session")
+	playSpins := fs.Int("play", 1_000, "Spins bet in an exploiting session")
+	targets := fs.Int("targets", 1, "Number of straight-up pockets bet in an exploiting session")
+	unitBet := casino.MoneyFlag(fs, "bet", casino.Dollars(10), "Straight-up bet on each target pocket")
+	seed := fs.Int64("seed", 0, "Seed of the simulated spins; runs with the same seed see the same spins (0 for a random seed)")
+
+	if code, ok := parseFlags(fs, args, config, "roulette"); !ok {
+		return code
+	}
+
+	pockets := roulette.Pockets(*european)
+	if *top < 0 {
+		return usageError(fmt.Errorf("top must be 0 or more, not %d", *top))
+	}
+	if *top > pockets {
+		*top = pockets
+	}
+	if *sectorWidth < 1 || *sectorWidth >= pockets {
+		return usageError(fmt.Errorf("a sector of a %d pocket wheel is 1 to %d pockets wide, not %d", pockets, pockets-1, *sectorWidth))
+	}
+	if *targets < 1 || *targets > pockets {
+		return usageError(fmt.Errorf("targets must be between 1 and %d, not %d", pockets, *targets))
+	}
+
+	runSeed := compareSeed(*seed)
+
+	var weights []float64
+	if *bias != "" {
+		var err error
+		if weights, err = roulette.ParseBias(*bias, *european); err != nil {
+			return usageError(err)
+		}
+	}
+	wheel, err := roulette.NewWheel(*european, weights)
+	if err != nil {
+		return fail(err)
+	}
+	fair, _ := roulette.NewWheel(*european, nil)
+	var source roulette.SpinSource = wheel
+	if *physics != "" {
+		if source, err = roulette.ParsePhysics(*physics, *european); err != nil {
+			return usageError(err)
+		}
+	}
+
+	spins, err := loadSpins(*spinFile, *european, roulette.Seeded(source, runSeed), *numSpins)
+	if err != nil {
+		return fail(err)
+	}
+	if len(spins) == 0 {
+		return fail(errors.New("no spins to analyze"))
+	}
+
+	// Test the spins against a fair wheel
+	probabilities := fair.Probabilities()
+	counts := roulette.Counts(spins, roulette.Pockets(*european))
+	chi := roulette.ChiSquare(counts, probabilities)
+
+	fmt.Printf("Analyzed %d spins\n", len(spins))
+	fmt.Printf("Chi-square: %.2f with %d degrees of freedom (p = %.4f)\n", chi.Statistic, chi.DegreesOfFreedom, chi.PValue)
+	if chi.PValue < *alpha {
+		fmt.Printf("The wheel looks biased at the %.2f%% level\n", *alpha*100)
+	} else {
+		fmt.Printf("No bias detected at the %.2f%% level\n", *alpha*100)
+	}
+
+	fmt.Println("\nHottest pockets:")
+	for _, score := range roulette.PocketZScores(counts, probabilities)[:*top] {
+		fmt.Printf("  %2s: %d hits (expected %.1f), z = %+.2f\n", roulette.PocketName(score.Pocket), score.Count, score.Expected, score.Z)
+	}
+
+	fmt.Println("\nHottest sectors:")
+	for _, score := range roulette.SectorZScores(counts, probabilities, roulette.WheelOrder(*european), *sectorWidth)[:*top] {
+		fmt.Printf("  %s: %d hits (expected %.1f), z = %+.2f\n", sectorName(score.Pockets), score.Count, score.Expected, score.Z)
+	}
+
+	if weights == nil {
+		return exitOK
+	}
+
+	// Estimate the detection effort and the edge the bias gives
+	biased := wheel.Probabilities()
+	needed := roulette.SpinsToDetect(probabilities, biased, *alpha, *power)
+	bestEdge := math.Inf(-1)
+	bestPocket := 0
+	for pocket, p := range biased {
+		if edge := 36*p - 1; edge > bestEdge {
+			bestEdge, bestPocket = edge, pocket
+		}
+	}
+	fmt.Printf("\nSpins needed to detect the bias (%.0f%% power): %d\n", *power*100, needed)
+	fmt.Printf("Best straight-up edge: %+.2f%% on %s\n", bestEdge*100, roulette.PocketName(bestPocket))
+
+	if *exploitSims == 0 {
+		return exitOK
+	}
+
+	// Simulate betting the pockets that come up most while watching the wheel
+	var totalNet, totalStaked casino.Money
+	profitable, hits := 0, 0
+	for i := 0; i < *exploitSims; i++ {
+		session := roulette.Seeded(wheel, casino.SessionSeed(runSeed, i)).(*roulette.Wheel)
+		result := roulette.ExploitBias(session, *learnSpins, *playSpins, *targets, *unitBet)
+		totalNet += result.Net
+		totalStaked += result.Staked
+		if result.Net > 0 {
+			profitable++
+		}
+		for _, target := range result.Targets {
+			if biased[target] > probabilities[target] {
+				hits++
+				break
+			}
+		}
+	}
+	fmt.Printf("\nAfter %d exploiting sessions (%d spins watched, %d bet):\n", *exploitSims, *learnSpins, *playSpins)
+	fmt.Printf("Found a biased pocket: %.2f%%\n", float64(hits)/float64(*exploitSims)*100)
+	fmt.Printf("Profitable sessions: %.2f%%\n", float64(profitable)/float64(*exploitSims)*100)
+	fmt.Printf("Average net: %.2f\n", totalNet.Float()/float64(*exploitSims))
+	fmt.Printf("Return on turnover: %+.2f%%\n", float64(totalNet)/float64(totalStaked)*100)
+	return exitOK
+}
+
+// Play rounds of a single bet and return the observed house edge
+func simulateEdge(v roulette.Variant, bet roulette.Bet, source roulette.SpinSource, rounds int) float64 {
+	var net, staked casino.Money
+	for i := 0; i < rounds; i++ {
+		settlement := v.Settle(bet, v.Spin(source))
+		net += settlement.Net
+		staked += settlement.Stake
+	}
+	return -float64(net) / float64(staked)
+}
+
+// analyzeVariants reports the house edge of every bet of each variant
+func analyzeVariants(args []string) int {
+	fs := newFlagSet("analyze variants", "Report the house edge of every bet of each roulette variant")
+
+	// Define command-line arguments
+	config := fs.String("config", "", "Scenario file (YAML or JSON) with the settings; flags override it")
+	variants := fs.String("variant", "european,la_partage,american,mini,double_ball,lightning", "Comma separated variants to report")
+	rounds := fs.Int("rounds", 0, "Rounds to simulate per bet to check the exact edge (0 to skip)")
+
+	if code, ok := parseFlags(fs, args, config, "roulette"); !ok {
+		return code
+	}
+
+	rand.Seed(time.Now().UnixNano())
+
+	for _, name := range strings.Split(*variants, ",") {
+		v, err := roulette.NewVariant(strings.TrimSpace(name))
+		if err != nil {
+			return usageError(err)
+		}
+		source := roulette.VariantWheel(v)
+
+		fmt.Printf("%s roulette (%d pockets):\n", v.Name(), v.Pockets())
+		for _, bet := range roulette.StandardBets(v) {
+			fmt.Printf("  %-20s house edge %6.3f%%", bet.String(), v.HouseEdge(bet)*100)
+			if *rounds > 0 {
+				fmt.Printf("  simulated %6.3f%%", simulateEdge(v, bet, source, *rounds)*100)
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+	}
+	return exitOK
+}
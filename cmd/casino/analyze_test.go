@@ -0,0 +1,71 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+	roulette "github.com/BryceWayne/casino/Roulette"
+)
+
+func TestChainMatchesSimulation(t *testing.T) {
+	// Steps of $1, $2 and $4 from $20, aiming for $10 of profit, on one bet
+	// and on a layout of two whose stakes together can outrun the balance
+	// before either does alone
+	cases := []struct {
+		bets  string
+		chain []roulette.ChainBet
+	}{
+		{"high", []roulette.ChainBet{{Numbers: roulette.Range(19, 36), Payout: 1}}},
+		{"high,dozen 3", []roulette.ChainBet{{Numbers: roulette.Range(19, 36), Payout: 1}, {Numbers: roulette.Range(25, 36), Payout: 2}}},
+	}
+	for _, c := range cases {
+		chain := roulette.Chain{
+			Bets:       c.chain,
+			Steps:      []casino.Money{casino.Dollars(1), casino.Dollars(2), casino.Dollars(4)},
+			Pockets:    roulette.Pockets(true),
+			Balance:    casino.Dollars(20),
+			ProfitGoal: casino.Dollars(10),
+		}
+		exact, err := chain.Solve(1e-12, 1_000_000)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The same sessions played by roulette sim on seeded spins
+		const sessions = 20_000
+		fs := newFlagSet("roulette sim", "")
+		f := defineRouletteFlags(fs)
+		args := append([]string{"-bets", c.bets}, strings.Fields("-european -progression steps -steps 1,2,4 -resetonwin -atend restart -balance 20 -profit 10")...)
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		s, err := f.setup()
+		if err != nil {
+			t.Fatal(err)
+		}
+		won := 0
+		spins := make([]float64, sessions)
+		results, err := s.runSessions(s.progressions, sessions, 11, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, result := range results {
+			if result.Balance >= s.initialBalance+s.profitGoal {
+				won++
+			}
+			spins[i] = float64(result.SpinCount)
+		}
+
+		// Both must agree within four standard errors
+		winRate := float64(won) / sessions
+		if se := math.Sqrt(exact.SuccessProbability * (1 - exact.SuccessProbability) / sessions); math.Abs(winRate-exact.SuccessProbability) > 4*se {
+			t.Errorf("%s: simulated win rate %.4f, exact %.4f (standard error %.4f)", c.bets, winRate, exact.SuccessProbability, se)
+		}
+		mean, variance := meanVariance(spins)
+		if se := math.Sqrt(variance / sessions); math.Abs(mean-exact.ExpectedSpins) > 4*se {
+			t.Errorf("%s: simulated %.3f spins a session, exact %.3f (standard error %.3f)", c.bets, mean, exact.ExpectedSpins, se)
+		}
+	}
+}
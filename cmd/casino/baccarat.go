@@ -0,0 +1,403 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	baccarat "github.com/BryceWayne/casino/Baccarat"
+	"github.com/cheggaaa/pb/v3"
+)
+
+// baccaratPlay sits the player at an interactive table, or deals a
+// single hand and shows how it was drawn
+func baccaratPlay(args []string) int {
+	fs := newFlagSet("baccarat play", "Play baccarat at an interactive table")
+
+	// Define command-line arguments
+	config := fs.String("config", "", "Scenario file (YAML or JSON) with the settings; flags override it")
+	numDecks := fs.Int("decks", 8, "Number of decks in the shoe")
+	initialBalance := casino.MoneyFlag(fs, "balance", casino.Dollars(10_000), "Player's balance, unless the wallet holds one")
+	tableLimit := casino.MoneyFlag(fs, "tablelimit", casino.Dollars(2000), "Table limit for each bet")
+	houseEdge := fs.Float64("houseEdge", 0.95, "House edge for Banker bet wins")
+	commissionRule := fs.String("commission", "exact", "How Banker commission is paid (exact, round or owed)")
+	chip := casino.MoneyFlag(fs, "chip", casino.Dollars(1), "Smallest chip, used to round payouts and owed commission")
+	walletFile := fs.String("wallet", "", "Keep the balance in this file from one session to the next")
+	seed := fs.Int64("seed", 0, "Seed of the shoes (0 for a random seed)")
+	once := fs.Bool("once", false, "Deal a single hand without bets and exit")
+
+	if code, ok := parseFlags(fs, args, config, "baccarat"); !ok {
+		return code
+	}
+
+	// Initialize and shuffle the deck
+	deck := baccarat.NewShoe(*numDecks)
+	if *seed != 0 {
+		deck.Rand = casino.NewRand(*seed)
+	}
+	deck.Shuffle()
+
+	if *once {
+		// Deal initial hands
+		playerHand, bankerHand := baccarat.DealInitialHands(deck)
+
+		// Deal third cards based on Baccarat rules
+		baccarat.DealThirdCard(deck, &playerHand, &bankerHand)
+
+		// Determine the winner
+		winner := baccarat.DetermineWinner(playerHand, bankerHand)
+
+		// Print results
+		fmt.Printf("Player's hand: %+v (value: %d)\n", playerHand.Cards, playerHand.Value())
+		fmt.Printf("Banker's hand: %+v (value: %d)\n", bankerHand.Cards, bankerHand.Value())
+		fmt.Printf("Winner: %s\n", winner)
+		return exitOK
+	}
+
+	rule, err := casino.ParseCommissionRule(*commissionRule)
+	if err != nil {
+		return usageError(err)
+	}
+	balance := *initialBalance
+	if *walletFile != "" {
+		saved, ok, err := loadWallet(*walletFile)
+		if err != nil {
+			return fail(err)
+		}
+		if ok {
+			balance = saved
+			fmt.Printf("Balance %s from %s\n", balance, *walletFile)
+		}
+	}
+	if balance <= 0 {
+		return fail(fmt.Errorf("no money to play with; give a -balance or remove the wallet %s", *walletFile))
+	}
+
+	table := &baccaratTable{
+		out:        os.Stdout,
+		deck:       deck,
+		numDecks:   *numDecks,
+		commission: casino.Commission{Rate: 1 - *houseEdge, Rule: rule, Unit: *chip},
+		tableLimit: *tableLimit,
+		balance:    balance,
+		start:      balance,
+		wins:       map[string]int{},
+	}
+	table.play(os.Stdin)
+
+	if *walletFile != "" {
+		if err := saveWallet(*walletFile, table.balance); err != nil {
+			return fail(err)
+		}
+		fmt.Printf("Balance saved to %s\n", *walletFile)
+	}
+	return exitOK
+}
+
+// baccaratFlags are the settings of a baccarat simulation, shared by the
+// commands that run one
+type baccaratFlags struct {
+	config         *string
+	playerName     *string
+	initialBet     *casino.Money
+	initialBalance *casino.Money
+	profitGoal     *casino.Money
+	stopLoss       *casino.Money
+	maxHands       *int
+	numSimulations *int
+	seed           *int64
+	tableLimit     *casino.Money
+	numDecks       *int
+	houseEdge      *float64
+	commissionRule *string
+	chip           *casino.Money
+	kellyFraction  *float64
+	kellyCap       *float64
+	kellyBet       *string
+	edge           *float64
+	variance       *float64
+	estimateHands  *int
+	lossRebate     *float64
+	rolling        *float64
+}
+
+// defineBaccaratFlags defines the flags of a baccarat simulation
+func defineBaccaratFlags(fs *flag.FlagSet) *baccaratFlags {
+	return &baccaratFlags{
+		config:         fs.String("config", "", "Scenario file (YAML or JSON) with the settings; flags override it"),
+		playerName:     fs.String("name", "Player", "Player's name"),
+		initialBet:     casino.MoneyFlag(fs, "bet", casino.Dollars(100), "Initial bet value"),
+		initialBalance: casino.MoneyFlag(fs, "balance", casino.Dollars(10_000), "Player's balance (optional)"),
+		profitGoal:     casino.MoneyFlag(fs, "profit", casino.Dollars(1_000), "Profit goal"),
+		stopLoss:       casino.MoneyFlag(fs, "stoploss", 0, "Stop loss: quit when the balance falls below it"),
+		maxHands:       fs.Int("maxhands", 0, "Hands after which a session stops (0 for no cap)"),
+		numSimulations: fs.Int("simulations", 100_000, "Number of simulations to run"),
+		seed:           fs.Int64("seed", 0, "Seed of the shoes; runs with the same seed are dealt the same shoes (0 for a random seed)"),
+		tableLimit:     casino.MoneyFlag(fs, "tablelimit", casino.Dollars(2000), "Table limit for betting"),
+		numDecks:       fs.Int("decks", 6, "Number of decks in the shoe"),
+		houseEdge:      fs.Float64("houseEdge", 0.95, "House edge for Banker bet wins"),
+		commissionRule: fs.String("commission", "exact", "How Banker commission is paid (exact, round or owed)"),
+		chip:           casino.MoneyFlag(fs, "chip", casino.Dollars(1), "Smallest chip, used to round payouts and owed commission"),
+		kellyFraction:  fs.Float64("kelly", 0, "Size bets with this share of the full Kelly stake instead of the doubling strategy (0 for the doubling strategy)"),
+		kellyCap:       fs.Float64("kellycap", 0, "Largest share of the balance a Kelly bet stakes (0 for no cap)"),
+		kellyBet:       fs.String("kellybet", "Banker", "Bet placed with Kelly sizing (Player, Banker or Tie)"),
+		edge:           fs.Float64("edge", 0, "Edge per unit staked of the Kelly bet (0 to estimate it by dealing hands)"),
+		variance:       fs.Float64("variance", 0, "Variance per unit staked of the Kelly bet (0 to estimate it by dealing hands)"),
+		estimateHands:  fs.Int("estimate", 200_000, "Hands dealt to estimate the edge and variance of the Kelly bet"),
+		lossRebate:     fs.Float64("lossrebate", 0, "Share of a session's net loss paid back when it ends (0 for none)"),
+		rolling:        fs.Float64("rolling", 0, "Rolling-chip commission: share of everything staked paid back when a session ends (0 for none)"),
+	}
+}
+
+// baccaratSetup is a baccarat simulation ready to run
+type baccaratSetup struct {
+	playerName     string
+	initialBet     casino.Money
+	initialBalance casino.Money
+	profitGoal     casino.Money
+	stopLoss       casino.Money
+	maxHands       int
+	tableLimit     casino.Money
+	numDecks       int
+	commission     casino.Commission
+	kelly          casino.Kelly
+	kellyBet       string
+	rebate         casino.Rebate
+	record         func(balance casino.Money, won bool, rounds int) // Called as each session finishes, when set
+	stop           <-chan struct{}                                  // Closed to skip the sessions not yet started, when set
+}
+
+// setup builds the commission, Kelly sizing and rebate program from the
+// flags, returning an error when a setting is invalid
+func (f *baccaratFlags) setup() (*baccaratSetup, error) {
+	if *f.numDecks < 1 {
+		return nil, fmt.Errorf("a shoe needs at least one deck, not %d", *f.numDecks)
+	}
+	if *f.initialBet <= 0 {
+		return nil, fmt.Errorf("bet must be positive, not %s", *f.initialBet)
+	}
+	if *f.tableLimit <= 0 {
+		return nil, fmt.Errorf("table limit must be positive, not %s", *f.tableLimit)
+	}
+	rule, err := casino.ParseCommissionRule(*f.commissionRule)
+	if err != nil {
+		return nil, err
+	}
+	commission := casino.Commission{Rate: 1 - *f.houseEdge, Rule: rule, Unit: *f.chip}
+
+	var kelly casino.Kelly
+	if *f.kellyFraction > 0 {
+		if *f.kellyBet != "Player" && *f.kellyBet != "Banker" && *f.kellyBet != "Tie" {
+			return nil, fmt.Errorf("unknown Kelly bet %q", *f.kellyBet)
+		}
+		kelly = casino.Kelly{Edge: *f.edge, Variance: *f.variance, Fraction: *f.kellyFraction, Cap: *f.kellyCap}
+		if kelly.Edge == 0 || kelly.Variance == 0 {
+			estimatedEdge, estimatedVariance := baccarat.EstimateOdds(*f.kellyBet, *f.numDecks, *f.estimateHands, commission)
+			if kelly.Edge == 0 {
+				kelly.Edge = estimatedEdge
+			}
+			if kelly.Variance == 0 {
+				kelly.Variance = estimatedVariance
+			}
+		}
+		if err := kelly.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	rebate := casino.Rebate{LossRate: *f.lossRebate, RollingRate: *f.rolling}
+	if err := rebate.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &baccaratSetup{
+		playerName:     *f.playerName,
+		initialBet:     *f.initialBet,
+		initialBalance: *f.initialBalance,
+		profitGoal:     *f.profitGoal,
+		stopLoss:       *f.stopLoss,
+		maxHands:       *f.maxHands,
+		tableLimit:     *f.tableLimit,
+		numDecks:       *f.numDecks,
+		commission:     commission,
+		kelly:          kelly,
+		kellyBet:       *f.kellyBet,
+		rebate:         rebate,
+	}, nil
+}
+
+// runSessions runs the sessions on one worker per CPU, each session
+// shuffling its shoes from a seed derived from the run's, so runs sharing a
+// seed are dealt the same cards and results stay in session order. Hand
+// histories are kept only when asked. Sessions skipped once stop is closed
+// are left as zero results.
+func (s *baccaratSetup) runSessions(numSimulations int, seed int64, keepHistory bool, bar *pb.ProgressBar) ([]baccarat.Result, [][]baccarat.GameHistory) {
+	results := make([]baccarat.Result, numSimulations)
+	histories := make([][]baccarat.GameHistory, numSimulations)
+	sessions := make(chan int)
+	var done sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			for i := range sessions {
+				if !stopped(s.stop) {
+					resultChan := make(chan baccarat.Result, 1)
+					historyChan := make(chan []baccarat.GameHistory, 1)
+					var wg sync.WaitGroup
+					wg.Add(1)
+					baccarat.RunSimulation(s.playerName, s.initialBet, s.initialBalance, s.profitGoal, s.stopLoss, s.maxHands, s.tableLimit, s.numDecks, casino.SessionSeed(seed, i), s.commission, s.kelly, s.kellyBet, s.rebate, resultChan, historyChan, &wg)
+					results[i] = <-resultChan
+					if s.record != nil {
+						s.record(results[i].Balance, results[i].Won, results[i].Hands)
+					}
+					if history := <-historyChan; keepHistory {
+						histories[i] = history
+					}
+				}
+				if bar != nil {
+					bar.Increment()
+				}
+			}
+		}()
+	}
+	for i := 0; i < numSimulations && !stopped(s.stop); i++ {
+		sessions <- i
+	}
+	close(sessions)
+	done.Wait()
+	return results, histories
+}
+
+// baccaratSim runs many sessions of the doubling strategy, or of Kelly
+// sizing, and reports the win rate
+func baccaratSim(args []string) int {
+	fs := newFlagSet("baccarat sim", "Simulate the doubling strategy or Kelly sizing over many sessions")
+
+	// Define command-line arguments
+	f := defineBaccaratFlags(fs)
+	historyFile := fs.String("history", "game_history.json", "Save the history of every hand to this file (empty to skip)")
+	resultsFile := fs.String("results", "", "Write one CSV row per session to this file")
+	showDashboard := fs.Bool("dashboard", false, "Show live statistics in the terminal instead of the progress bar")
+
+	if code, ok := parseFlags(fs, args, f.config, "baccarat"); !ok {
+		return code
+	}
+	s, err := f.setup()
+	if err != nil {
+		return usageError(err)
+	}
+	if s.kelly.Fraction > 0 {
+		fmt.Printf("Kelly sizing on %s: edge %+.4f, variance %.4f, staking %.2f%% of the balance\n", s.kellyBet, s.kelly.Edge, s.kelly.Variance, s.kelly.Share()*100)
+	}
+	seed := *f.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	// Run simulations concurrently
+	var results []baccarat.Result
+	var histories [][]baccarat.GameHistory
+	if *showDashboard {
+		strategy := "doubling strategy"
+		if s.kelly.Fraction > 0 {
+			strategy = fmt.Sprintf("Kelly sizing on %s", s.kellyBet)
+		}
+		d := newDashboard(os.Stdout, fmt.Sprintf("Baccarat: %s with %d decks, seed %d", strategy, s.numDecks, seed), "hands", *f.numSimulations)
+		s.record = d.record
+		d.start()
+		results, histories = s.runSessions(*f.numSimulations, seed, *historyFile != "", nil)
+		d.finish()
+		fmt.Println()
+	} else {
+		bar := pb.StartNew(*f.numSimulations)
+		results, histories = s.runSessions(*f.numSimulations, seed, *historyFile != "", bar)
+		bar.Finish()
+	}
+
+	// Collect results
+	winCount := 0
+	cappedCount := 0
+	var allGameHistories []baccarat.GameHistory
+	drawdowns := make([]float64, 0, *f.numSimulations)
+	growth, growthHands, ruined := 0.0, 0, 0
+	var net, turnover, rebates casino.Money
+	sessions := make([]casino.SessionResult, 0, *f.numSimulations)
+	for _, result := range results {
+		outcome := "lost"
+		if result.Won {
+			winCount++
+			outcome = "won"
+		} else if result.Capped {
+			cappedCount++
+			outcome = "capped"
+		}
+		sessions = append(sessions, casino.SessionResult{Outcome: outcome, Balance: result.Balance, Rounds: result.Hands, MaxDrawdown: result.MaxDrawdown})
+		drawdowns = append(drawdowns, result.MaxDrawdown)
+		net += result.Balance - s.initialBalance
+		turnover += result.Turnover
+		rebates += result.Rebate
+		if g := casino.LogGrowth(s.initialBalance, result.Balance, 1); math.IsInf(g, -1) {
+			ruined++
+		} else {
+			growth += g
+			growthHands += result.Hands
+		}
+	}
+
+	for _, history := range histories {
+		allGameHistories = append(allGameHistories, history...)
+	}
+
+	// Save the complete game history to JSON file
+	code := exitOK
+	if *historyFile != "" {
+		if err := baccarat.SaveGameHistory(*historyFile, allGameHistories); err != nil {
+			fmt.Fprintln(os.Stderr, "Error saving game history:", err)
+			code = exitError
+		} else {
+			fmt.Println("Game history saved successfully.")
+		}
+	}
+	if *resultsFile != "" {
+		if err := casino.WriteResults(*resultsFile, sessions); err != nil {
+			code = fail(err)
+		}
+	}
+
+	// Report win rate
+	winRate := float64(winCount) / float64(*f.numSimulations) * 100
+	fmt.Printf("Win rate after %d simulations: %.2f%%\n", *f.numSimulations, winRate)
+	if s.maxHands > 0 {
+		fmt.Printf("Sessions stopped at the hand cap: %d\n", cappedCount)
+	}
+
+	// The rebate program is judged by the edge it leaves over everything staked
+	n := float64(*f.numSimulations)
+	fmt.Printf("Turnover per session: %.2f\n", turnover.Float()/n)
+	if turnover > 0 {
+		fmt.Printf("Effective edge: %+.4f%% without the rebate program", float64(net-rebates)/float64(turnover)*100)
+		if s.rebate != (casino.Rebate{}) {
+			fmt.Printf(", %+.4f%% with it, paying %.2f per session", float64(net)/float64(turnover)*100, rebates.Float()/n)
+		}
+		fmt.Println()
+	}
+
+	// Kelly sizing is judged by how fast the balance grows and how far it falls on the way
+	if s.kelly.Fraction > 0 {
+		if growthHands > 0 {
+			growth /= float64(growthHands)
+		}
+		fmt.Printf("Growth rate per hand: %.4f%% (expected %.4f%%)\n", growth*100, s.kelly.Growth()*100)
+		fmt.Printf("Sessions ruined: %d\n", ruined)
+		fmt.Printf("Max drawdown: median %.1f%%, 90th percentile %.1f%%, 99th percentile %.1f%%, worst %.1f%%\n",
+			casino.Percentile(drawdowns, 50)*100, casino.Percentile(drawdowns, 90)*100, casino.Percentile(drawdowns, 99)*100, casino.Percentile(drawdowns, 100)*100)
+	}
+
+	return code
+}
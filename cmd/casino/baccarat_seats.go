@@ -0,0 +1,197 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	casino "github.com/BryceWayne/casino"
+	baccarat "github.com/BryceWayne/casino/Baccarat"
+	"github.com/cheggaaa/pb/v3"
+)
+
+// tableFlags are the baccarat flags every seat of a table shares
+var tableFlags = []string{"decks", "tablelimit", "houseEdge", "commission", "chip", "maxhands", "seats"}
+
+// baccaratSeatsFlags defines the flags of the baccarat seats command
+func baccaratSeatsFlags() (*flag.FlagSet, *baccaratFlags, *armList, *int) {
+	fs := newFlagSet("baccarat seats", "Simulate several seats betting on one shared shoe")
+	f := defineBaccaratFlags(fs)
+	seats := &armList{}
+	fs.Var(seats, "seat", "A seat, as settings over the other flags such as \"name=Ann;kelly=0.5\" (repeat for each)")
+	numSeats := fs.Int("seats", 7, "Number of seats playing the other flags when no -seat is given")
+	return fs, f, seats, numSeats
+}
+
+// seat returns a seat playing the setup's bankroll, goals, strategy and
+// rebate program
+func (s *baccaratSetup) seat(name string) baccarat.Seat {
+	return baccarat.Seat{
+		Name:           name,
+		InitialBet:     s.initialBet,
+		InitialBalance: s.initialBalance,
+		ProfitGoal:     s.profitGoal,
+		StopLoss:       s.stopLoss,
+		Kelly:          s.kelly,
+		KellyBet:       s.kellyBet,
+		Rebate:         s.rebate,
+	}
+}
+
+// defaultSeats returns numSeats seats all playing the setup
+func defaultSeats(s *baccaratSetup, numSeats int) []baccarat.Seat {
+	seats := make([]baccarat.Seat, numSeats)
+	for i := range seats {
+		seats[i] = s.seat("Seat " + strconv.Itoa(i+1))
+	}
+	return seats
+}
+
+// baccaratSeats deals each session from one shoe to a full table of seats,
+// each with its own bankroll and strategy, and reports every seat and what
+// the table meant for the house
+func baccaratSeats(args []string) int {
+	fs, f, seatList, numSeats := baccaratSeatsFlags()
+	if code, ok := parseFlags(fs, args, f.config, "baccarat"); !ok {
+		return code
+	}
+	seed := compareSeed(*f.seed)
+	numSimulations := *f.numSimulations
+	table, err := f.setup()
+	if err != nil {
+		return usageError(err)
+	}
+
+	// Build every seat over the shared flags, or copies of them
+	var seats []baccarat.Seat
+	if len(*seatList) == 0 {
+		if *numSeats < 1 || *numSeats > baccarat.MaxSeats {
+			return usageError(fmt.Errorf("a table seats 1 to %d players, not %d", baccarat.MaxSeats, *numSeats))
+		}
+		seats = defaultSeats(table, *numSeats)
+	} else {
+		if len(*seatList) > baccarat.MaxSeats {
+			return usageError(fmt.Errorf("a table seats at most %d players, not %d", baccarat.MaxSeats, len(*seatList)))
+		}
+		for i, seat := range *seatList {
+			for _, setting := range seat.settings {
+				for _, name := range tableFlags {
+					if setting[0] == name {
+						return usageError(fmt.Errorf("%s: -%s is shared by the whole table", seat.label, name))
+					}
+				}
+			}
+			fs, f, _, _ := baccaratSeatsFlags()
+			if code, ok := parseFlags(fs, args, f.config, "baccarat"); !ok {
+				return code
+			}
+			if err := seat.apply(fs); err != nil {
+				return usageError(err)
+			}
+			s, err := f.setup()
+			if err != nil {
+				return usageError(fmt.Errorf("%s: %v", seat.label, err))
+			}
+			name := s.playerName
+			if name == "Player" {
+				name = "Seat " + strconv.Itoa(i+1)
+			}
+			seats = append(seats, s.seat(name))
+		}
+	}
+
+	// Run the tables concurrently, table i shuffling from a seed derived from the run's
+	fmt.Printf("Dealing %d tables of %d seats from shared shoes with seed %d\n", numSimulations, len(seats), seed)
+	t := baccarat.Table{Seats: seats, NumDecks: table.numDecks, TableLimit: table.tableLimit, Commission: table.commission}
+	results := make([]baccarat.TableResult, numSimulations)
+	bar := pb.StartNew(numSimulations)
+	var done sync.WaitGroup
+	for i := 0; i < numSimulations; i++ {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			defer bar.Increment()
+			results[i] = t.Play(table.maxHands, casino.SessionSeed(seed, i))
+		}(i)
+	}
+	done.Wait()
+	bar.Finish()
+
+	printSeats(seats, results)
+	return exitOK
+}
+
+// printSeats reports how each seat did over every table, then the house's
+// side: its result per table against what it would be if the seats had
+// been dealt from shoes of their own
+func printSeats(seats []baccarat.Seat, results []baccarat.TableResult) {
+	n := float64(len(results))
+	fmt.Printf("\n%-20s %10s %10s %14s %12s %14s %10s\n", "Seat", "Win rate", "Ruined", "Mean net", "Avg hands", "Wagered", "Rebate")
+	independent := 0.0
+	for i, seat := range seats {
+		won, ruined, hands := 0, 0, 0
+		nets := make([]float64, len(results))
+		var wagered, rebate casino.Money
+		for j, r := range results {
+			s := r.Seats[i]
+			if s.Won {
+				won++
+			}
+			if s.Balance <= 0 {
+				ruined++
+			}
+			hands += s.Hands
+			wagered += s.Turnover
+			rebate += s.Rebate
+			nets[j] = (s.Balance - seat.InitialBalance).Float()
+		}
+		mean, variance := meanVariance(nets)
+		independent += variance
+		fmt.Printf("%-20s %9.2f%% %9.2f%% %14.2f %12.2f %14.2f %10.2f\n", seat.Name, float64(won)/n*100, float64(ruined)/n*100, mean, float64(hands)/n, wagered.Float()/n, rebate.Float()/n)
+	}
+
+	houseNets := make([]float64, len(results))
+	worstHands := make([]float64, len(results))
+	drawdowns := make([]float64, len(results))
+	var wagered, houseNet casino.Money
+	hands := 0
+	for i, r := range results {
+		houseNets[i] = r.HouseNet.Float()
+		worstHands[i] = r.HouseWorstHand.Float()
+		drawdowns[i] = r.HouseDrawdown.Float()
+		wagered += r.Wagered
+		houseNet += r.HouseNet
+		hands += r.Hands
+	}
+	mean, variance := meanVariance(houseNets)
+	fmt.Printf("\nHouse, per table of %.1f hands on average:\n", float64(hands)/n)
+	if wagered > 0 {
+		fmt.Printf("  Hold: %.3f%% of %.2f wagered\n", houseNet.Float()/wagered.Float()*100, wagered.Float()/n)
+	}
+	fmt.Printf("  Net: mean %.2f, std dev %.2f (%.2f if every seat had its own shoe)\n", mean, math.Sqrt(variance), math.Sqrt(independent))
+	fmt.Printf("  Net: 1st percentile %.2f, 5th percentile %.2f, worst %.2f\n", casino.Percentile(houseNets, 1), casino.Percentile(houseNets, 5), casino.Percentile(houseNets, 0))
+	fmt.Printf("  Worst single hand: median %.2f, 99th percentile %.2f\n", casino.Percentile(worstHands, 50), casino.Percentile(worstHands, 99))
+	fmt.Printf("  Drawdown: median %.2f, 99th percentile %.2f, worst %.2f\n", casino.Percentile(drawdowns, 50), casino.Percentile(drawdowns, 99), casino.Percentile(drawdowns, 100))
+}
+
+// meanVariance returns the mean and sample variance of the values
+func meanVariance(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	sumOfSquares := 0.0
+	for _, v := range values {
+		sumOfSquares += (v - mean) * (v - mean)
+	}
+	return mean, sumOfSquares / float64(len(values)-1)
+}
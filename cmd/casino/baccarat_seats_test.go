@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+	baccarat "github.com/BryceWayne/casino/Baccarat"
+)
+
+func TestDefaultSeats(t *testing.T) {
+	fs, f, _, numSeats := baccaratSeatsFlags()
+	if err := fs.Parse(strings.Fields("-seats 3 -lossrebate 0.1 -rolling 0.005 -maxhands 50")); err != nil {
+		t.Fatal(err)
+	}
+	s, err := f.setup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	seats := defaultSeats(s, *numSeats)
+	want := casino.Rebate{LossRate: 0.1, RollingRate: 0.005}
+	if len(seats) != 3 || seats[2].Name != "Seat 3" {
+		t.Fatalf("seats = %+v", seats)
+	}
+	for _, seat := range seats {
+		if seat.Rebate != want || seat.InitialBet != s.initialBet || seat.InitialBalance != s.initialBalance {
+			t.Errorf("%s = %+v, want the shared flags and rebate %+v", seat.Name, seat, want)
+		}
+	}
+
+	// Every seat that staked anything is paid its rolling commission
+	table := baccarat.Table{Seats: seats, NumDecks: s.numDecks, TableLimit: s.tableLimit, Commission: s.commission}
+	for _, r := range table.Play(s.maxHands, 9).Seats {
+		if r.Turnover == 0 || r.Rebate < r.Turnover.Scale(0.005) {
+			t.Errorf("%s staked %s and was paid %s", r.Name, r.Turnover, r.Rebate)
+		}
+	}
+}
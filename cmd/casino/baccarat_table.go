@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	casino "github.com/BryceWayne/casino"
+	baccarat "github.com/BryceWayne/casino/Baccarat"
+)
+
+// tableBet is a bet placed at the interactive table
+type tableBet struct {
+	kind   string
+	amount casino.Money
+}
+
+// betNames maps what a player may type to the bet it places
+var betNames = map[string]string{
+	"player": "Player", "p": "Player",
+	"banker": "Banker", "b": "Banker",
+	"tie": "Tie", "t": "Tie",
+	"player pair": baccarat.PlayerPair, "pp": baccarat.PlayerPair,
+	"banker pair": baccarat.BankerPair, "bp": baccarat.BankerPair,
+}
+
+// parseTableBets parses bets such as "banker 100, tie 10, pp 5"
+func parseTableBets(text string) ([]tableBet, error) {
+	var bets []tableBet
+	for _, field := range strings.Split(text, ",") {
+		words := strings.Fields(strings.ToLower(field))
+		if len(words) < 2 {
+			return nil, fmt.Errorf("invalid bet %q, want a bet and an amount such as \"banker 100\"", strings.TrimSpace(field))
+		}
+		kind, ok := betNames[strings.Join(words[:len(words)-1], " ")]
+		if !ok {
+			return nil, fmt.Errorf("unknown bet %q", strings.Join(words[:len(words)-1], " "))
+		}
+		amount, err := casino.ParseMoney(words[len(words)-1])
+		if err != nil || amount <= 0 {
+			return nil, fmt.Errorf("invalid amount %q", words[len(words)-1])
+		}
+		bets = append(bets, tableBet{kind: kind, amount: amount})
+	}
+	return bets, nil
+}
+
+// cardSuits are the symbols the table draws suits with
+var cardSuits = map[string]string{"Hearts": "♥", "Diamonds": "♦", "Clubs": "♣", "Spades": "♠"}
+
+// formatHand shows the cards of a hand and its value
+func formatHand(hand baccarat.Hand) string {
+	cards := make([]string, len(hand.Cards))
+	for i, card := range hand.Cards {
+		cards[i] = card.Rank + cardSuits[card.Suit]
+	}
+	return fmt.Sprintf("%-14s = %d", strings.Join(cards, " "), hand.Value())
+}
+
+// baccaratTable is an interactive table: a real shoe dealt hand after
+// hand, the player's bankroll and the scoreboard
+type baccaratTable struct {
+	out        io.Writer
+	deck       *baccarat.Deck
+	numDecks   int
+	commission casino.Commission
+	tableLimit casino.Money
+	balance    casino.Money
+	start      casino.Money
+
+	road        []string       // Winner of every hand of this shoe
+	wins        map[string]int // Wins of each side over the session
+	hands       int
+	wagered     casino.Money
+	commissions casino.Money
+	biggestWin  casino.Money
+	biggestLoss casino.Money
+	lastBets    []tableBet
+}
+
+// newShoe starts a fresh shoe, collecting the commission owed on the old one
+func (t *baccaratTable) newShoe() {
+	rng := t.deck.Rand
+	*t.deck = *baccarat.NewShoe(t.numDecks)
+	t.deck.Rand = rng
+	t.deck.Shuffle()
+	t.road = nil
+	fmt.Fprintf(t.out, "New shoe of %d decks\n", t.numDecks)
+	t.collect()
+}
+
+// collect pays the commission owed
+func (t *baccaratTable) collect() {
+	if owed := t.commission.Collect(); owed > 0 {
+		t.balance -= owed
+		t.commissions += owed
+		fmt.Fprintf(t.out, "Commission owed collected: %s\n", owed)
+	}
+}
+
+// check refuses bets the player cannot cover or the table does not take
+func (t *baccaratTable) check(bets []tableBet) error {
+	var total casino.Money
+	for _, bet := range bets {
+		if bet.amount > t.tableLimit {
+			return fmt.Errorf("%s %s is over the table limit of %s", bet.kind, bet.amount, t.tableLimit)
+		}
+		total += bet.amount
+	}
+	if available := t.balance - t.commission.Owed; total > available {
+		return fmt.Errorf("bets of %s are more than the %s you have", total, available)
+	}
+	return nil
+}
+
+// deal plays one hand with the bets, showing the cards and the draw
+// decisions, and settles every bet
+func (t *baccaratTable) deal(bets []tableBet) {
+	// Start a new shoe if it's close to being empty, like the simulator
+	if len(t.deck.Cards) < 6 {
+		t.newShoe()
+	}
+
+	playerHand, bankerHand := baccarat.DealInitialHands(t.deck)
+	fmt.Fprintf(t.out, "Player: %s\n", formatHand(baccarat.Hand{Cards: playerHand.Cards[:2]}))
+	fmt.Fprintf(t.out, "Banker: %s\n", formatHand(baccarat.Hand{Cards: bankerHand.Cards[:2]}))
+	draws := baccarat.PlayThirdCards(t.deck, &playerHand, &bankerHand)
+	fmt.Fprintln(t.out, draws.Player)
+	if !draws.Natural {
+		if draws.PlayerDraws {
+			fmt.Fprintf(t.out, "Player: %s\n", formatHand(playerHand))
+		}
+		fmt.Fprintln(t.out, draws.Banker)
+		if draws.BankerDraws {
+			fmt.Fprintf(t.out, "Banker: %s\n", formatHand(bankerHand))
+		}
+	}
+
+	winner := baccarat.DetermineWinner(playerHand, bankerHand)
+	switch winner {
+	case "Player":
+		fmt.Fprintf(t.out, "Player wins %d to %d\n", playerHand.Value(), bankerHand.Value())
+	case "Banker":
+		fmt.Fprintf(t.out, "Banker wins %d to %d\n", bankerHand.Value(), playerHand.Value())
+	default:
+		fmt.Fprintf(t.out, "Tie at %d\n", playerHand.Value())
+	}
+
+	var net casino.Money
+	for _, bet := range bets {
+		var settlement casino.Settlement
+		if bet.kind == baccarat.PlayerPair || bet.kind == baccarat.BankerPair {
+			settlement = baccarat.SettleSideBet(bet.kind, bet.amount, playerHand, bankerHand)
+		} else {
+			settlement = baccarat.SettleBet(bet.kind, bet.amount, winner, &t.commission)
+			if bet.kind == "Banker" && settlement.Outcome == casino.Win {
+				t.commissions += bet.amount - settlement.Net
+			}
+		}
+		fmt.Fprintf(t.out, "  %-12s %8s: %s %s\n", bet.kind, bet.amount, settlement.Outcome, settlement.Net)
+		net += settlement.Net
+		t.wagered += bet.amount
+	}
+	t.balance += net
+	if net > t.biggestWin {
+		t.biggestWin = net
+	}
+	if -net > t.biggestLoss {
+		t.biggestLoss = -net
+	}
+	t.hands++
+	t.wins[winner]++
+	t.road = append(t.road, winner)
+	fmt.Fprintf(t.out, "Net %s, balance %s\n", net, t.balance)
+	if t.commission.Owed > 0 {
+		fmt.Fprintf(t.out, "Commission owed: %s\n", t.commission.Owed)
+	}
+	t.scoreboard()
+}
+
+// scoreboard shows the winners of this shoe, newest last
+func (t *baccaratTable) scoreboard() {
+	counts := map[string]int{}
+	road := make([]string, 0, len(t.road))
+	for _, winner := range t.road {
+		counts[winner]++
+		road = append(road, winner[:1])
+	}
+	if len(road) > 40 {
+		road = road[len(road)-40:]
+	}
+	fmt.Fprintf(t.out, "Shoe: Player %d, Banker %d, Tie %d | %s\n", counts["Player"], counts["Banker"], counts["Tie"], strings.Join(road, " "))
+}
+
+// summary reports the session when the player leaves the table
+func (t *baccaratTable) summary() {
+	fmt.Fprintln(t.out, "\nSession summary")
+	fmt.Fprintf(t.out, "Hands played:    %d (Player %d, Banker %d, Tie %d)\n", t.hands, t.wins["Player"], t.wins["Banker"], t.wins["Tie"])
+	fmt.Fprintf(t.out, "Total wagered:   %s\n", t.wagered)
+	fmt.Fprintf(t.out, "Commission paid: %s\n", t.commissions)
+	fmt.Fprintf(t.out, "Biggest win:     %s\n", t.biggestWin)
+	fmt.Fprintf(t.out, "Biggest loss:    %s\n", t.biggestLoss)
+	fmt.Fprintf(t.out, "Result:          %s, from %s to %s\n", t.balance-t.start, t.start, t.balance)
+}
+
+// play takes bets at the prompt and deals until the player quits, runs
+// out of money or the input ends
+func (t *baccaratTable) play(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	for t.balance-t.commission.Owed > 0 {
+		fmt.Fprintf(t.out, "\nBalance %s. Bets such as \"banker 100, pp 5\", Enter to repeat, s for the scoreboard, q to quit: ", t.balance)
+		if !scanner.Scan() {
+			fmt.Fprintln(t.out)
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		switch strings.ToLower(line) {
+		case "q", "quit", "exit":
+			t.collect()
+			t.summary()
+			return
+		case "s", "scoreboard":
+			t.scoreboard()
+			continue
+		case "h", "help", "?":
+			fmt.Fprintln(t.out, "Bets: player (p) and banker (b) pay 1:1, Banker less commission; tie (t) pays 8:1; player pair (pp) and banker pair (bp) pay 11:1")
+			continue
+		}
+
+		bets := t.lastBets
+		if line != "" {
+			var err error
+			if bets, err = parseTableBets(line); err != nil {
+				fmt.Fprintln(t.out, "Error:", err)
+				continue
+			}
+		} else if len(bets) == 0 {
+			fmt.Fprintln(t.out, "No bets to repeat yet")
+			continue
+		}
+		if err := t.check(bets); err != nil {
+			fmt.Fprintln(t.out, "Error:", err)
+			continue
+		}
+		t.lastBets = bets
+		t.deal(bets)
+	}
+	if t.balance-t.commission.Owed <= 0 {
+		fmt.Fprintln(t.out, "\nOut of money")
+	}
+	t.collect()
+	t.summary()
+}
+
+// wallet is the bankroll kept between sessions
+type wallet struct {
+	Balance casino.Money `json:"balance"`
+}
+
+// loadWallet reads the bankroll saved by the last session, false when there is none
+func loadWallet(path string) (casino.Money, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	var w wallet
+	if err := json.Unmarshal(data, &w); err != nil {
+		return 0, false, fmt.Errorf("%s: %v", path, err)
+	}
+	return w.Balance, true, nil
+}
+
+// saveWallet keeps the bankroll for the next session
+func saveWallet(path string, balance casino.Money) error {
+	data, err := json.MarshalIndent(wallet{Balance: balance}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+	baccarat "github.com/BryceWayne/casino/Baccarat"
+)
+
+func TestParseTableBets(t *testing.T) {
+	bets, err := parseTableBets("Banker 100, tie 10, player pair 2.50")
+	want := []tableBet{{"Banker", casino.Dollars(100)}, {"Tie", casino.Dollars(10)}, {baccarat.PlayerPair, 250}}
+	if err != nil || len(bets) != len(want) {
+		t.Fatalf("parseTableBets = %v, %v", bets, err)
+	}
+	for i := range want {
+		if bets[i] != want[i] {
+			t.Errorf("bet %d = %+v, want %+v", i, bets[i], want[i])
+		}
+	}
+	for _, text := range []string{"banker", "dragon 5", "tie -5", "player 0"} {
+		if _, err := parseTableBets(text); err == nil {
+			t.Errorf("parseTableBets(%q) accepted", text)
+		}
+	}
+}
+
+func TestBaccaratTable(t *testing.T) {
+	var out bytes.Buffer
+	deck := baccarat.NewShoe(1)
+	deck.Rand = casino.NewRand(1)
+	deck.Shuffle()
+	table := &baccaratTable{
+		out:        &out,
+		deck:       deck,
+		numDecks:   1,
+		commission: casino.Commission{Rate: 0.05, Rule: casino.CommissionExact},
+		tableLimit: casino.Dollars(500),
+		balance:    casino.Dollars(1000),
+		start:      casino.Dollars(1000),
+		wins:       map[string]int{},
+	}
+	table.play(strings.NewReader("banker 100\n\n\nplayer 600\nq\n"))
+
+	if table.hands != 3 {
+		t.Errorf("played %d hands, want 3", table.hands)
+	}
+	if table.wagered != casino.Dollars(300) {
+		t.Errorf("wagered %s, want $300", table.wagered)
+	}
+	if !strings.Contains(out.String(), "over the table limit") || !strings.Contains(out.String(), "Session summary") {
+		t.Errorf("missing the table limit error or the summary:\n%s", out.String())
+	}
+	if got := table.wins["Player"] + table.wins["Banker"] + table.wins["Tie"]; got != 3 {
+		t.Errorf("scoreboard counts %d hands, want 3", got)
+	}
+}
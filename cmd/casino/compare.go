@@ -0,0 +1,293 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	"github.com/cheggaaa/pb/v3"
+)
+
+// arm is one strategy of a comparison: settings given over the shared flags
+type arm struct {
+	label    string
+	settings [][2]string
+}
+
+// armList collects the repeated -arm flag
+type armList []arm
+
+func (l *armList) String() string {
+	labels := make([]string, len(*l))
+	for i, a := range *l {
+		labels[i] = a.label
+	}
+	return strings.Join(labels, " ")
+}
+
+func (l *armList) Set(value string) error {
+	a, err := parseArm(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, a)
+	return nil
+}
+
+// parseArm parses settings such as "strategy=fib;bet=150", separated by
+// semicolons since bet layouts use commas and colons
+func parseArm(text string) (arm, error) {
+	a := arm{label: strings.TrimSpace(text)}
+	for _, field := range strings.Split(text, ";") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(field, "=")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "-")
+		if !ok || name == "" {
+			return arm{}, fmt.Errorf("invalid setting %q in %q, want name=value", field, text)
+		}
+		a.settings = append(a.settings, [2]string{name, strings.TrimSpace(value)})
+	}
+	if len(a.settings) == 0 {
+		return arm{}, fmt.Errorf("no settings in %q", text)
+	}
+	return a, nil
+}
+
+// apply sets the arm's settings on a flag set the command line was parsed into
+func (a arm) apply(fs *flag.FlagSet) error {
+	for _, setting := range a.settings {
+		switch setting[0] {
+		case "config", "arm", "confidence", "seed", "simulations":
+			return fmt.Errorf("%s: cannot set -%s for a single strategy", a.label, setting[0])
+		}
+		if fs.Lookup(setting[0]) == nil {
+			return fmt.Errorf("%s: unknown flag -%s", a.label, setting[0])
+		}
+		if err := fs.Set(setting[0], setting[1]); err != nil {
+			return fmt.Errorf("%s: invalid value %q for -%s: %v", a.label, setting[1], setting[0], err)
+		}
+	}
+	return nil
+}
+
+// armOutcome is how each session of one strategy ended
+type armOutcome struct {
+	label  string
+	won    []bool
+	net    []float64
+	rounds []int
+}
+
+// checkArms checks the shared settings of a comparison
+func checkArms(arms armList, confidence float64) error {
+	if len(arms) < 2 {
+		return fmt.Errorf("give at least two strategies to compare with -arm")
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return fmt.Errorf("confidence %v is not between 0 and 1", confidence)
+	}
+	return nil
+}
+
+// printComparison reports each strategy, then the paired difference of
+// every pair in success rate and net per session. The significance level
+// is split between the pairs (Bonferroni) so comparing many strategies
+// does not turn up differences by chance.
+func printComparison(outcomes []armOutcome, confidence float64, rounds string) {
+	fmt.Printf("\n%-40s %10s %14s %12s\n", "Strategy", "Win rate", "Mean net", "Avg "+rounds)
+	for _, o := range outcomes {
+		won, net, total := 0, 0.0, 0
+		for i := range o.won {
+			if o.won[i] {
+				won++
+			}
+			net += o.net[i]
+			total += o.rounds[i]
+		}
+		n := float64(len(o.won))
+		fmt.Printf("%-40s %9.2f%% %14.2f %12.2f\n", o.label, float64(won)/n*100, net/n, float64(total)/n)
+	}
+
+	pairs := len(outcomes) * (len(outcomes) - 1) / 2
+	level := (1 - confidence) / float64(pairs)
+	fmt.Printf("\nPaired differences with %.4g%% confidence intervals, significant when p < %.4g", (1-level)*100, level)
+	if pairs > 1 {
+		fmt.Printf(" (%.0f%% overall, split over %d pairs)", confidence*100, pairs)
+	}
+	fmt.Println(":")
+	verdict := func(c casino.Comparison) string {
+		if c.Significant(level) {
+			return "significant"
+		}
+		return "not significant"
+	}
+	for i := range outcomes {
+		for j := i + 1; j < len(outcomes); j++ {
+			a, b := outcomes[i], outcomes[j]
+			success := casino.McNemar(a.won, b.won, 1-level)
+			net := casino.PairedDifference(a.net, b.net, 1-level)
+			fmt.Printf("\n%s minus %s\n", a.label, b.label)
+			fmt.Printf("  Win rate: %+.2f points [%+.2f, %+.2f], p = %.4g, %s\n", success.Mean*100, success.Low*100, success.High*100, success.P, verdict(success))
+			fmt.Printf("  Net per session: %+.2f [%+.2f, %+.2f], p = %.4g, %s\n", net.Mean, net.Low, net.High, net.P, verdict(net))
+		}
+	}
+}
+
+// compareSeed returns the seed of a comparison, picking one when it is 0
+func compareSeed(seed int64) int64 {
+	if seed == 0 {
+		return time.Now().UnixNano()
+	}
+	return seed
+}
+
+// rouletteCompareFlags defines the flags of the roulette compare command
+func rouletteCompareFlags() (*flag.FlagSet, *rouletteFlags, *armList, *float64) {
+	fs := newFlagSet("roulette compare", "Play several strategies on the same spins and test their differences")
+	f := defineRouletteFlags(fs)
+	arms := &armList{}
+	fs.Var(arms, "arm", "Strategy to compare, as settings over the other flags such as \"strategy=fib;bet=150\" (repeat for each)")
+	confidence := fs.Float64("confidence", 0.95, "Confidence of the intervals; the significance level is what is left, split over the pairs")
+	return fs, f, arms, confidence
+}
+
+// rouletteCompare plays several strategies on the same spins, session i of
+// every strategy spinning from the same seed, and tests their differences
+func rouletteCompare(args []string) int {
+	fs, f, arms, confidence := rouletteCompareFlags()
+	if code, ok := parseFlags(fs, args, f.config, "roulette"); !ok {
+		return code
+	}
+	if err := checkArms(*arms, *confidence); err != nil {
+		return usageError(err)
+	}
+	seed := compareSeed(*f.seed)
+	numSimulations := *f.numSimulations
+
+	// Build every strategy before running any, so a bad setting fails fast
+	setups, err := rouletteArms(args, *arms)
+	if err != nil {
+		return usageError(err)
+	}
+
+	fmt.Printf("Comparing %d strategies over %d sessions on the same spins with seed %d\n", len(setups), numSimulations, seed)
+	bar := pb.StartNew(len(setups) * numSimulations)
+	outcomes, err := playRouletteArms(setups, *arms, numSimulations, seed, bar)
+	bar.Finish()
+	if err != nil {
+		return fail(err)
+	}
+
+	printComparison(outcomes, *confidence, "spins")
+	return exitOK
+}
+
+// rouletteArms builds the setup of every strategy from the command line
+// with the strategy's settings over it
+func rouletteArms(args []string, arms armList) ([]*rouletteSetup, error) {
+	setups := make([]*rouletteSetup, len(arms))
+	for i, a := range arms {
+		fs, f, _, _ := rouletteCompareFlags()
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if err := casino.ApplyConfig(fs, *f.config, "roulette"); err != nil {
+			return nil, err
+		}
+		if err := a.apply(fs); err != nil {
+			return nil, err
+		}
+		if err := applyStrategy(fs, *f.strategy); err != nil {
+			return nil, err
+		}
+		setup, err := f.setup()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", a.label, err)
+		}
+		setups[i] = setup
+	}
+	return setups, nil
+}
+
+// playRouletteArms runs the sessions of every strategy from the same seed,
+// so session i of each spins the same wheel
+func playRouletteArms(setups []*rouletteSetup, arms armList, numSimulations int, seed int64, bar *pb.ProgressBar) ([]armOutcome, error) {
+	outcomes := make([]armOutcome, len(setups))
+	for i, s := range setups {
+		o := armOutcome{label: arms[i].label}
+		results, err := s.runSessions(s.progressions, numSimulations, seed, bar)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			o.won = append(o.won, result.Balance >= s.initialBalance+s.profitGoal)
+			o.net = append(o.net, (result.Balance - s.initialBalance).Float())
+			o.rounds = append(o.rounds, result.SpinCount)
+		}
+		outcomes[i] = o
+	}
+	return outcomes, nil
+}
+
+// baccaratCompareFlags defines the flags of the baccarat compare command
+func baccaratCompareFlags() (*flag.FlagSet, *baccaratFlags, *armList, *float64) {
+	fs := newFlagSet("baccarat compare", "Play several strategies on the same shoes and test their differences")
+	f := defineBaccaratFlags(fs)
+	arms := &armList{}
+	fs.Var(arms, "arm", "Strategy to compare, as settings over the other flags such as \"kelly=0.5;kellybet=Banker\" (repeat for each)")
+	confidence := fs.Float64("confidence", 0.95, "Confidence of the intervals; the significance level is what is left, split over the pairs")
+	return fs, f, arms, confidence
+}
+
+// baccaratCompare plays several strategies on the same shoes, session i of
+// every strategy shuffling from the same seed, and tests their differences
+func baccaratCompare(args []string) int {
+	fs, f, arms, confidence := baccaratCompareFlags()
+	if code, ok := parseFlags(fs, args, f.config, "baccarat"); !ok {
+		return code
+	}
+	if err := checkArms(*arms, *confidence); err != nil {
+		return usageError(err)
+	}
+	seed := compareSeed(*f.seed)
+	numSimulations := *f.numSimulations
+
+	// Build every strategy before running any, so a bad setting fails fast
+	setups := make([]*baccaratSetup, len(*arms))
+	for i, a := range *arms {
+		fs, f, _, _ := baccaratCompareFlags()
+		if code, ok := parseFlags(fs, args, f.config, "baccarat"); !ok {
+			return code
+		}
+		if err := a.apply(fs); err != nil {
+			return usageError(err)
+		}
+		setup, err := f.setup()
+		if err != nil {
+			return usageError(fmt.Errorf("%s: %v", a.label, err))
+		}
+		setups[i] = setup
+	}
+
+	fmt.Printf("Comparing %d strategies over %d sessions on the same shoes with seed %d\n", len(setups), numSimulations, seed)
+	bar := pb.StartNew(len(setups) * numSimulations)
+	outcomes := make([]armOutcome, len(setups))
+	for i, s := range setups {
+		o := armOutcome{label: (*arms)[i].label}
+		results, _ := s.runSessions(numSimulations, seed, false, bar)
+		for _, result := range results {
+			o.won = append(o.won, result.Won)
+			o.net = append(o.net, (result.Balance - s.initialBalance).Float())
+			o.rounds = append(o.rounds, result.Hands)
+		}
+		outcomes[i] = o
+	}
+	bar.Finish()
+
+	printComparison(outcomes, *confidence, "hands")
+	return exitOK
+}
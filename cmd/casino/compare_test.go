@@ -0,0 +1,93 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArm(t *testing.T) {
+	a, err := parseArm(" strategy=fib; -bet=150 ;bets=red:25,black ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]string{{"strategy", "fib"}, {"bet", "150"}, {"bets", "red:25,black"}}
+	if a.label != "strategy=fib; -bet=150 ;bets=red:25,black" || !reflect.DeepEqual(a.settings, want) {
+		t.Errorf("parseArm = %+v, want settings %v", a, want)
+	}
+	for _, text := range []string{"", " ; ", "fib", "=fib", "strategy=fib;bet"} {
+		if _, err := parseArm(text); err == nil {
+			t.Errorf("parseArm(%q) accepted", text)
+		}
+	}
+
+	fs, _, arms, _ := rouletteCompareFlags()
+	if err := fs.Parse([]string{"-arm", "strategy=fib", "-arm", "strategy=martingale;bet=50"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*arms) != 2 || arms.String() != "strategy=fib strategy=martingale;bet=50" {
+		t.Errorf("arms = %v", *arms)
+	}
+}
+
+func TestRouletteArmsRejectBadFlags(t *testing.T) {
+	for _, text := range []string{
+		"nope=1",
+		"seed=3",
+		"simulations=10",
+		"bet=abc",
+		"strategy=nope",
+		"progression=fibonacci;bets=dozen 4",
+	} {
+		a, err := parseArm(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rouletteArms(nil, armList{a}); err == nil {
+			t.Errorf("arm %q accepted", text)
+		}
+	}
+}
+
+func TestCompareSameSpins(t *testing.T) {
+	var arms armList
+	for _, text := range []string{"strategy=martingale", "strategy=martingale;progression=flat;bets=black"} {
+		if err := arms.Set(text); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setups, err := rouletteArms([]string{"-seed", "42"}, arms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorders := make([]*spinRecorder, len(setups))
+	for i, s := range setups {
+		recorders[i] = newSpinRecorder(s.source)
+		s.source = recorders[i]
+	}
+	outcomes, err := playRouletteArms(setups, arms, 50, 42, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 || len(outcomes[0].rounds) != 50 || reflect.DeepEqual(outcomes[0].rounds, outcomes[1].rounds) {
+		t.Fatalf("outcomes = %+v, want 50 sessions of each lasting differently", outcomes)
+	}
+
+	// Session i of each strategy sees the same spins for as long as both play
+	martingale, flat := recorders[0].sessions, recorders[1].sessions
+	if len(martingale) != 50 || len(flat) != 50 {
+		t.Fatalf("%d and %d sessions seeded, want 50", len(martingale), len(flat))
+	}
+	for key, spins := range martingale {
+		other, ok := flat[key]
+		if !ok {
+			t.Fatalf("session %d was seeded for martingale but not flat", key)
+		}
+		a, b := *spins, *other
+		if len(a) > len(b) {
+			a, b = b, a
+		}
+		if !reflect.DeepEqual(a, b[:len(a)]) {
+			t.Errorf("the strategies spun differently from the same seed: %v and %v", a, b[:len(a)])
+		}
+	}
+}
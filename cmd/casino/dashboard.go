@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// dashboard redraws the statistics of a running simulation in the
+// terminal as sessions finish, in place of the progress bar
+type dashboard struct {
+	out     io.Writer
+	title   string
+	rounds  string // What a session counts, "spins" or "hands"
+	total   int
+	refresh time.Duration
+
+	mu       sync.Mutex
+	started  time.Time
+	won      int
+	played   int
+	balances []float64
+	lengths  []float64
+	band     [][3]float64 // Win rate and its interval at each redraw
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// dashboardConfidence is the confidence of the win rate band
+const dashboardConfidence = 0.95
+
+// newDashboard returns a dashboard for a run of total sessions
+func newDashboard(out io.Writer, title, rounds string, total int) *dashboard {
+	return &dashboard{
+		out:      out,
+		title:    title,
+		rounds:   rounds,
+		total:    total,
+		refresh:  250 * time.Millisecond,
+		balances: make([]float64, 0, total),
+		lengths:  make([]float64, 0, total),
+	}
+}
+
+// start clears the screen and redraws it until finish is called
+func (d *dashboard) start() {
+	d.started = time.Now()
+	d.stop = make(chan struct{})
+	d.done = make(chan struct{})
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(d.refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.draw()
+			case <-d.stop:
+				d.draw()
+				return
+			}
+		}
+	}()
+}
+
+// record adds a finished session
+func (d *dashboard) record(balance casino.Money, won bool, rounds int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if won {
+		d.won++
+	}
+	d.played += rounds
+	d.balances = append(d.balances, balance.Float())
+	d.lengths = append(d.lengths, float64(rounds))
+}
+
+// finish draws the final frame and stops redrawing
+func (d *dashboard) finish() {
+	close(d.stop)
+	<-d.done
+}
+
+// draw samples the win rate band and redraws the screen
+func (d *dashboard) draw() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if n := len(d.balances); n > 0 {
+		low, high := casino.WilsonInterval(d.won, n, dashboardConfidence)
+		d.band = append(d.band, [3]float64{float64(d.won) / float64(n), low, high})
+	}
+	fmt.Fprint(d.out, "\x1b[H\x1b[2J"+d.render(time.Since(d.started)))
+}
+
+// render lays out the dashboard: progress and throughput, the win rate
+// and its band, then the final balances and session lengths
+func (d *dashboard) render(elapsed time.Duration) string {
+	var b strings.Builder
+	n := len(d.balances)
+	fmt.Fprintf(&b, "%s\n\n", d.title)
+
+	// Progress and throughput
+	width := 50
+	filled := 0
+	if d.total > 0 {
+		filled = n * width / d.total
+	}
+	fmt.Fprintf(&b, "[%s%s] %d/%d sessions (%.1f%%)\n", strings.Repeat("#", filled), strings.Repeat(" ", width-filled), n, d.total, float64(n)/float64(d.total)*100)
+	seconds := elapsed.Seconds()
+	if seconds > 0 {
+		remaining := "-"
+		if n > 0 && n < d.total {
+			remaining = (time.Duration(float64(d.total-n) / float64(n) * float64(elapsed))).Round(time.Second).String()
+		}
+		fmt.Fprintf(&b, "Elapsed %s, remaining %s, %.0f sessions/s, %.0f %s/s\n", elapsed.Round(time.Second), remaining, float64(n)/seconds, float64(d.played)/seconds, d.rounds)
+	}
+	if n == 0 {
+		return b.String()
+	}
+
+	// Win rate with the band narrowing as sessions come in
+	low, high := casino.WilsonInterval(d.won, n, dashboardConfidence)
+	fmt.Fprintf(&b, "\nWin rate %.2f%%, %.0f%% interval [%.2f%%, %.2f%%]\n", float64(d.won)/float64(n)*100, dashboardConfidence*100, low*100, high*100)
+	for _, line := range bandChart(d.band, 60, 8) {
+		fmt.Fprintln(&b, line)
+	}
+
+	fmt.Fprintln(&b, "\nFinal balance")
+	for _, line := range histogram(d.balances, 10, 40, func(v float64) string { return casino.Money(math.Round(v * 100)).String() }) {
+		fmt.Fprintln(&b, line)
+	}
+	fmt.Fprintf(&b, "\n%s%s per session\n", strings.ToUpper(d.rounds[:1]), d.rounds[1:])
+	for _, line := range histogram(d.lengths, 10, 40, func(v float64) string { return fmt.Sprintf("%.0f", v) }) {
+		fmt.Fprintln(&b, line)
+	}
+	return b.String()
+}
+
+// bandChart plots the last samples of an estimate, '*', inside its
+// interval, '|', with the oldest sample on the left
+func bandChart(samples [][3]float64, width, height int) []string {
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	bottom, top := math.Inf(1), math.Inf(-1)
+	for _, s := range samples {
+		bottom = math.Min(bottom, s[1])
+		top = math.Max(top, s[2])
+	}
+	if top-bottom < 1e-9 {
+		top, bottom = top+0.005, bottom-0.005
+	}
+	row := func(v float64) int {
+		return int(math.Round((top - v) / (top - bottom) * float64(height-1)))
+	}
+
+	lines := make([]string, height)
+	for r := 0; r < height; r++ {
+		cells := make([]byte, len(samples))
+		for c, s := range samples {
+			switch {
+			case row(s[0]) == r:
+				cells[c] = '*'
+			case r >= row(s[2]) && r <= row(s[1]):
+				cells[c] = '|'
+			default:
+				cells[c] = ' '
+			}
+		}
+		label := ""
+		if r == 0 {
+			label = fmt.Sprintf("%.2f%%", top*100)
+		} else if r == height-1 {
+			label = fmt.Sprintf("%.2f%%", bottom*100)
+		}
+		lines[r] = fmt.Sprintf("%8s |%s", label, cells)
+	}
+	return lines
+}
+
+// histogram draws the values as horizontal bars over equal bins, labelling
+// each bin with its lower bound
+func histogram(values []float64, bins, width int, label func(float64) string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+	if high == low {
+		return []string{fmt.Sprintf("%12s %s %d", label(low), strings.Repeat("#", width), len(values))}
+	}
+
+	counts := make([]int, bins)
+	step := (high - low) / float64(bins)
+	for _, v := range values {
+		i := int((v - low) / step)
+		if i >= bins {
+			i = bins - 1
+		}
+		counts[i]++
+	}
+	largest := 0
+	for _, count := range counts {
+		if count > largest {
+			largest = count
+		}
+	}
+	lines := make([]string, bins)
+	for i, count := range counts {
+		bar := strings.Repeat("#", count*width/largest)
+		lines[i] = fmt.Sprintf("%12s %-*s %d", label(low+float64(i)*step), width, bar, count)
+	}
+	return lines
+}
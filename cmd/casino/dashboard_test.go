@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+func TestHistogram(t *testing.T) {
+	lines := histogram([]float64{0, 1, 1, 2, 9, 10}, 5, 10, func(v float64) string { return "" })
+	if len(lines) != 5 {
+		t.Fatalf("got %d bins, want 5", len(lines))
+	}
+	if !strings.HasSuffix(lines[0], " 3") || !strings.HasSuffix(lines[4], " 2") || !strings.Contains(lines[0], strings.Repeat("#", 10)) {
+		t.Errorf("histogram = %q", lines)
+	}
+	if lines := histogram([]float64{5, 5}, 5, 10, func(v float64) string { return "" }); len(lines) != 1 {
+		t.Errorf("histogram of equal values = %q, want one bar", lines)
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	var out bytes.Buffer
+	d := newDashboard(&out, "Test run", "spins", 4)
+	d.refresh = time.Hour
+	d.start()
+	d.record(casino.Dollars(150), true, 10)
+	d.record(0, false, 30)
+	d.record(casino.Dollars(150), true, 20)
+	d.finish()
+
+	screen := out.String()
+	for _, want := range []string{"Test run", "3/4 sessions", "Win rate 66.67%", "Final balance", "Spins per session", "$135"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("dashboard is missing %q:\n%s", want, screen)
+		}
+	}
+	if len(d.band) != 1 || d.band[0][1] > 2.0/3 || d.band[0][2] < 2.0/3 {
+		t.Errorf("band = %v, want one sample around 2/3", d.band)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/BryceWayne/casino/floor"
+)
+
+// floorSim simulates a casino floor for a number of days and reports the
+// house's side: drop, handle, theoretical and actual win, hold and the
+// swing of each table's day
+func floorSim(args []string) int {
+	fs := newFlagSet("floor", "Simulate a casino floor from the house's side: drop, hold and win per table")
+
+	// Define command-line arguments
+	config := fs.String("config", "", "Floor file (YAML or JSON) with the tables and players (default: a mid-sized floor)")
+	days := fs.Int("days", 30, "Number of days to simulate")
+	arrivals := fs.Float64("arrivals", 0, "Players arriving per hour, overriding the floor file (0 to keep it)")
+	seed := fs.Int64("seed", 0, "Seed of the days; runs with the same seed see the same days (0 for a random seed)")
+
+	if code, ok := parseFlags(fs, args, nil, ""); !ok {
+		return code
+	}
+	f := floor.Default()
+	if *config != "" {
+		var err error
+		if f, err = floor.Load(*config); err != nil {
+			return usageError(err)
+		}
+	}
+	if *arrivals != 0 {
+		f.ArrivalsPerHour = *arrivals
+	}
+	if *days < 1 {
+		return usageError(fmt.Errorf("days must be positive, not %d", *days))
+	}
+	runSeed := compareSeed(*seed)
+
+	results, err := f.Run(*days, runSeed)
+	if err != nil {
+		return usageError(err)
+	}
+	arrived, turnedAway := 0, 0
+	for _, day := range results {
+		arrived += day.Arrivals
+		turnedAway += day.TurnedAway
+	}
+	fmt.Printf("%d days of %d hours with seed %d: %d players arrived, %d found no seat\n", *days, f.HoursPerDay, runSeed, arrived, turnedAway)
+
+	fmt.Printf("\n%-22s %6s %14s %15s %13s %13s %7s %10s %12s %12s %7s\n", "Tables", "Count", "Drop", "Handle", "Theo win", "Win", "Hold", "Win/hour", "Daily mean", "Daily sd", "Losing")
+	for _, s := range f.Summarize(results) {
+		fmt.Printf("%-22s %6d %14.2f %15.2f %13.2f %13.2f %6.2f%% %10.2f %12.2f %12.2f %6.1f%%\n",
+			s.Name, s.Tables, s.Drop.Float(), s.Handle.Float(), s.Theo.Float(), s.Win.Float(), s.Hold*100, s.WinPerHour.Float(), s.DailyMean, s.DailyStdDev, s.LosingDays*100)
+	}
+	fmt.Println("\nDaily figures are for one table of a group, and for the whole floor on its row.")
+	return exitOK
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	"github.com/BryceWayne/casino/simulation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcConfidence is the confidence of the win rate intervals the service reports
+const grpcConfidence = 0.95
+
+// grpcMaxSimulations is the most sessions one request can run
+const grpcMaxSimulations = 1_000_000
+
+// simulator runs the simulations of the sim commands for gRPC clients
+type simulator struct {
+	simulation.UnimplementedSimulatorServer
+	interval time.Duration // Between progress updates
+}
+
+// scenarioFromProto converts a scenario message to the scenario of a file
+func scenarioFromProto(m *simulation.Scenario) *casino.Scenario {
+	cents := func(list []int64) []casino.Money {
+		money := make([]casino.Money, len(list))
+		for i, c := range list {
+			money[i] = casino.Money(c)
+		}
+		return money
+	}
+	t, p := m.GetTable(), m.GetProgression()
+	s := &casino.Scenario{
+		Game:     m.GetGame(),
+		Strategy: m.GetStrategy(),
+		Variant:  m.GetVariant(),
+		Table: casino.TableRules{
+			Decks:          int(t.GetDecks()),
+			Commission:     t.GetCommission(),
+			CommissionRate: t.GetCommissionRate(),
+			Chips:          cents(t.GetChipsCents()),
+			ChipUnit:       casino.Money(t.GetChipUnitCents()),
+			Rounding:       t.GetRounding(),
+			InsideMin:      casino.Money(t.GetInsideMinCents()),
+			InsideMax:      casino.Money(t.GetInsideMaxCents()),
+			OutsideMin:     casino.Money(t.GetOutsideMinCents()),
+			OutsideMax:     casino.Money(t.GetOutsideMaxCents()),
+			TableMin:       casino.Money(t.GetTableMinCents()),
+			TableMax:       casino.Money(t.GetTableMaxCents()),
+			AtLimit:        t.GetAtLimit(),
+			Bias:           t.GetBias(),
+			Physics:        t.GetPhysics(),
+		},
+		Progression: casino.ProgressionSpec{
+			System:        p.GetSystem(),
+			Unit:          casino.Money(p.GetUnitCents()),
+			Steps:         cents(p.GetStepsCents()),
+			MaxSteps:      int(p.GetMaxSteps()),
+			AtEnd:         p.GetAtEnd(),
+			KellyFraction: p.GetKellyFraction(),
+			KellyCap:      p.GetKellyCap(),
+			KellyBet:      p.GetKellyBet(),
+			Edge:          p.GetEdge(),
+			Variance:      p.GetVariance(),
+		},
+		Bankroll:    casino.Money(m.GetBankrollCents()),
+		StopLoss:    casino.Money(m.GetStopLossCents()),
+		WinGoal:     casino.Money(m.GetWinGoalCents()),
+		MaxRounds:   int(m.GetMaxRounds()),
+		Simulations: int(m.GetSimulations()),
+	}
+	if p != nil {
+		s.Progression.ResetOnWin = p.ResetOnWin
+	}
+	for _, bet := range m.GetBets() {
+		s.Bets = append(s.Bets, casino.BetSpec{Bet: bet.GetBet(), Amount: casino.Money(bet.GetAmountCents())})
+	}
+	return s
+}
+
+// simulationRun is a scenario ready to run: its sessions, starting
+// bankroll, and a function that plays them, calling record as each ends
+// and starting no more once stop is closed
+type simulationRun struct {
+	sessions int
+	bankroll casino.Money
+	play     func(seed int64, stop <-chan struct{}, record func(balance casino.Money, won bool, rounds int)) ([]casino.SessionResult, error)
+}
+
+// prepare builds a run from a scenario the way the sim command of its
+// game would from a scenario file
+func prepare(scenario *casino.Scenario) (*simulationRun, error) {
+	switch scenario.Game {
+	case "roulette":
+		fs := newFlagSet("roulette sim", "Simulate a betting strategy over many sessions")
+		f := defineRouletteFlags(fs)
+		if err := casino.ApplyScenario(fs, scenario); err != nil {
+			return nil, err
+		}
+		if err := applyStrategy(fs, *f.strategy); err != nil {
+			return nil, err
+		}
+		s, err := f.setup()
+		if err != nil {
+			return nil, err
+		}
+		play := func(seed int64, stop <-chan struct{}, record func(balance casino.Money, won bool, rounds int)) ([]casino.SessionResult, error) {
+			s.record, s.stop = record, stop
+			results, err := s.runSessions(s.progressions, *f.numSimulations, seed, nil)
+			if err != nil {
+				return nil, err
+			}
+			sessions := make([]casino.SessionResult, len(results))
+			for i, result := range results {
+				outcome := "lost"
+				if result.Balance >= s.initialBalance+s.profitGoal {
+					outcome = "won"
+				} else if result.Capped {
+					outcome = "capped"
+				}
+				sessions[i] = casino.SessionResult{Outcome: outcome, Balance: result.Balance, Rounds: result.SpinCount, MaxDrawdown: result.MaxDrawdown}
+			}
+			return sessions, nil
+		}
+		return &simulationRun{sessions: *f.numSimulations, bankroll: s.initialBalance, play: play}, nil
+	case "baccarat":
+		fs := newFlagSet("baccarat sim", "Simulate the doubling strategy or Kelly sizing over many sessions")
+		f := defineBaccaratFlags(fs)
+		if err := casino.ApplyScenario(fs, scenario); err != nil {
+			return nil, err
+		}
+		s, err := f.setup()
+		if err != nil {
+			return nil, err
+		}
+		play := func(seed int64, stop <-chan struct{}, record func(balance casino.Money, won bool, rounds int)) ([]casino.SessionResult, error) {
+			s.record, s.stop = record, stop
+			results, _ := s.runSessions(*f.numSimulations, seed, false, nil)
+			sessions := make([]casino.SessionResult, len(results))
+			for i, result := range results {
+				outcome := "lost"
+				if result.Won {
+					outcome = "won"
+				} else if result.Capped {
+					outcome = "capped"
+				}
+				sessions[i] = casino.SessionResult{Outcome: outcome, Balance: result.Balance, Rounds: result.Hands, MaxDrawdown: result.MaxDrawdown}
+			}
+			return sessions, nil
+		}
+		return &simulationRun{sessions: *f.numSimulations, bankroll: s.initialBalance, play: play}, nil
+	}
+	return nil, fmt.Errorf("unknown game %q, want baccarat or roulette", scenario.Game)
+}
+
+// winRate returns a share of won sessions with its confidence interval
+func winRate(won, sessions int) (float64, float64, float64) {
+	if sessions == 0 {
+		return 0, 0, 0
+	}
+	low, high := casino.WilsonInterval(won, sessions, grpcConfidence)
+	return float64(won) / float64(sessions), low, high
+}
+
+// statistics summarizes the sessions of a run
+func statistics(seed int64, bankroll casino.Money, sessions []casino.SessionResult) *simulation.Statistics {
+	stats := &simulation.Statistics{Seed: seed, Sessions: int32(len(sessions))}
+	if len(sessions) == 0 {
+		return stats
+	}
+	nets := make([]float64, len(sessions))
+	balances := make([]float64, len(sessions))
+	drawdowns := make([]float64, len(sessions))
+	rounds, netSum := 0, 0.0
+	for i, session := range sessions {
+		switch session.Outcome {
+		case "won":
+			stats.Won++
+		case "capped":
+			stats.Capped++
+		}
+		if math.IsInf(casino.LogGrowth(bankroll, session.Balance, 1), -1) {
+			stats.Ruined++
+		}
+		nets[i] = (session.Balance - bankroll).Float()
+		balances[i] = session.Balance.Float()
+		drawdowns[i] = session.MaxDrawdown
+		rounds += session.Rounds
+		netSum += nets[i]
+	}
+
+	n := float64(len(sessions))
+	stats.WinRate, stats.WinRateLow, stats.WinRateHigh = winRate(int(stats.Won), len(sessions))
+	stats.MeanNet = netSum / n
+	if len(sessions) > 1 {
+		sumOfSquares := 0.0
+		for _, net := range nets {
+			sumOfSquares += (net - stats.MeanNet) * (net - stats.MeanNet)
+		}
+		stats.NetStdDev = math.Sqrt(sumOfSquares / (n - 1))
+	}
+	stats.AverageRounds = float64(rounds) / n
+	stats.MedianBalance = casino.Percentile(balances, 50)
+	stats.P5Balance = casino.Percentile(balances, 5)
+	stats.P95Balance = casino.Percentile(balances, 95)
+	stats.MedianDrawdown = casino.Percentile(drawdowns, 50)
+	stats.P95Drawdown = casino.Percentile(drawdowns, 95)
+	return stats
+}
+
+// Run simulates a scenario, sending progress every interval while the
+// sessions run and the statistics once they are all done. A client that
+// cancels or goes away stops the sessions not yet started.
+func (sim *simulator) Run(request *simulation.Scenario, stream simulation.Simulator_RunServer) error {
+	if n := request.GetSimulations(); n <= 0 || n > grpcMaxSimulations {
+		return status.Errorf(codes.InvalidArgument, "simulations must be from 1 to %d, not %d", grpcMaxSimulations, n)
+	}
+	run, err := prepare(scenarioFromProto(request))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	seed := request.GetSeed()
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	// Count the sessions as they finish, for the progress updates
+	var mu sync.Mutex
+	finished, won := 0, 0
+	started := time.Now()
+	record := func(balance casino.Money, sessionWon bool, rounds int) {
+		mu.Lock()
+		defer mu.Unlock()
+		finished++
+		if sessionWon {
+			won++
+		}
+	}
+	progress := func() *simulation.Update {
+		mu.Lock()
+		defer mu.Unlock()
+		p := &simulation.Progress{Sessions: int32(finished), Total: int32(run.sessions)}
+		p.WinRate, p.WinRateLow, p.WinRateHigh = winRate(won, finished)
+		if elapsed := time.Since(started).Seconds(); elapsed > 0 {
+			p.SessionsPerSecond = float64(finished) / elapsed
+		}
+		return &simulation.Update{Update: &simulation.Update_Progress{Progress: p}}
+	}
+
+	// Only this goroutine sends while the sessions run
+	stop := make(chan struct{})
+	sent := make(chan error, 1)
+	go func() {
+		ticker := time.NewTicker(sim.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := stream.Send(progress()); err != nil {
+					sent <- err
+					return
+				}
+			case <-stop:
+				sent <- nil
+				return
+			}
+		}
+	}()
+	ctx := stream.Context()
+	sessions, playErr := run.play(seed, ctx.Done(), record)
+	close(stop)
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	if err := <-sent; err != nil {
+		return err
+	}
+	if playErr != nil {
+		return status.Error(codes.Internal, playErr.Error())
+	}
+
+	if err := stream.Send(progress()); err != nil {
+		return err
+	}
+	return stream.Send(&simulation.Update{Update: &simulation.Update_Statistics{Statistics: statistics(seed, run.bankroll, sessions)}})
+}
+
+// newSimulationServer returns a gRPC server offering the Simulator service
+func newSimulationServer(interval time.Duration) *grpc.Server {
+	server := grpc.NewServer()
+	simulation.RegisterSimulatorServer(server, &simulator{interval: interval})
+	return server
+}
+
+// serveGRPC runs the gRPC simulation service
+func serveGRPC(args []string) int {
+	fs := newFlagSet("grpc", "Run baccarat and roulette simulations for gRPC clients, streaming their progress")
+
+	// Define command-line arguments
+	addr := fs.String("addr", "localhost:9090", "Address to listen on")
+	interval := fs.Duration("interval", time.Second, "Time between progress updates")
+
+	if code, ok := parseFlags(fs, args, nil, ""); !ok {
+		return code
+	}
+	if *interval <= 0 {
+		return usageError(fmt.Errorf("interval must be positive, not %s", *interval))
+	}
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Printf("Serving simulations over gRPC on %s\n", listener.Addr())
+	if err := newSimulationServer(*interval).Serve(listener); err != nil {
+		return fail(err)
+	}
+	return exitOK
+}
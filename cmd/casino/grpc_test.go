@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/BryceWayne/casino/simulation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialSimulator starts the service on an in-process listener and returns a client
+func dialSimulator(t *testing.T) simulation.SimulatorClient {
+	t.Helper()
+	client, _ := startSimulator(t)
+	return client
+}
+
+// startSimulator starts the service on an in-process listener and returns
+// a client and the server
+func startSimulator(t *testing.T) (simulation.SimulatorClient, *grpc.Server) {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := newSimulationServer(time.Millisecond)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return simulation.NewSimulatorClient(conn), server
+}
+
+// runScenario streams a run to the end, returning its progress and statistics
+func runScenario(t *testing.T, client simulation.SimulatorClient, scenario *simulation.Scenario) ([]*simulation.Progress, *simulation.Statistics, error) {
+	t.Helper()
+	stream, err := client.Run(context.Background(), scenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var progress []*simulation.Progress
+	var stats *simulation.Statistics
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return progress, stats, nil
+		}
+		if err != nil {
+			return progress, stats, err
+		}
+		if p := update.GetProgress(); p != nil {
+			progress = append(progress, p)
+		}
+		if s := update.GetStatistics(); s != nil {
+			stats = s
+		}
+	}
+}
+
+func TestSimulatorRoulette(t *testing.T) {
+	client := dialSimulator(t)
+	scenario := &simulation.Scenario{
+		Game:          "roulette",
+		Strategy:      "martingale",
+		Variant:       "european",
+		BankrollCents: 100_000,
+		WinGoalCents:  20_000,
+		MaxRounds:     500,
+		Simulations:   300,
+		Seed:          7,
+	}
+	progress, stats, err := runScenario(t, client, scenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) == 0 || stats == nil {
+		t.Fatalf("%d progress updates and statistics %v", len(progress), stats)
+	}
+	last := progress[len(progress)-1]
+	if last.Sessions != 300 || last.Total != 300 {
+		t.Errorf("last progress = %v, want 300 of 300 sessions", last)
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i].Sessions < progress[i-1].Sessions {
+			t.Fatalf("progress went back from %d to %d sessions", progress[i-1].Sessions, progress[i].Sessions)
+		}
+	}
+	if stats.Seed != 7 || stats.Sessions != 300 || stats.WinRate != float64(stats.Won)/300 || stats.WinRateLow > stats.WinRate || stats.WinRateHigh < stats.WinRate {
+		t.Errorf("statistics = %v", stats)
+	}
+	if stats.P5Balance > stats.MedianBalance || stats.MedianBalance > stats.P95Balance || stats.AverageRounds <= 0 || stats.AverageRounds > 500 {
+		t.Errorf("statistics = %v", stats)
+	}
+	if last.WinRate != stats.WinRate {
+		t.Errorf("final progress win rate %v, statistics %v", last.WinRate, stats.WinRate)
+	}
+
+	// The same seed plays the same sessions
+	_, again, err := runScenario(t, client, scenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Won != stats.Won || again.MeanNet != stats.MeanNet {
+		t.Errorf("seed 7 won %d then %d sessions", stats.Won, again.Won)
+	}
+}
+
+func TestSimulatorBaccarat(t *testing.T) {
+	client := dialSimulator(t)
+	_, stats, err := runScenario(t, client, &simulation.Scenario{
+		Game:        "baccarat",
+		Table:       &simulation.TableRules{Decks: 6, TableMaxCents: 100_000},
+		Progression: &simulation.Progression{UnitCents: 2_500},
+		MaxRounds:   200,
+		Simulations: 100,
+		Seed:        3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Sessions != 100 || stats.Won+stats.Capped > 100 || stats.AverageRounds <= 0 || stats.AverageRounds > 200 {
+		t.Errorf("statistics = %v", stats)
+	}
+}
+
+func TestSimulatorInvalidScenario(t *testing.T) {
+	client := dialSimulator(t)
+	for _, scenario := range []*simulation.Scenario{
+		{Game: "poker", Simulations: 10},
+		{Game: "roulette", Strategy: "nope", Simulations: 10},
+		{Game: "roulette", Variant: "la_partage", Bets: []*simulation.Bet{{Bet: "dozen 4"}}, Simulations: 10},
+		{Game: "baccarat", Table: &simulation.TableRules{Commission: "never"}, Simulations: 10},
+		{Game: "baccarat", Simulations: -1},
+		{Game: "baccarat"},
+		{Game: "roulette", Simulations: grpcMaxSimulations + 1},
+		{Game: "baccarat", Table: &simulation.TableRules{Decks: -1}, Simulations: 10},
+		{Game: "baccarat", Table: &simulation.TableRules{TableMaxCents: -100}, Simulations: 10},
+		{Game: "baccarat", Progression: &simulation.Progression{UnitCents: -500}, Simulations: 10},
+		{Game: "roulette", Progression: &simulation.Progression{UnitCents: -500}, Simulations: 10},
+		{Game: "roulette", Table: &simulation.TableRules{OutsideMaxCents: -100}, Simulations: 10},
+		{Game: "roulette", Variant: "europen", Simulations: 10},
+		{Game: "roulette", Variant: "mini", Simulations: 10},
+		{Game: "roulette", Variant: "lightning", Simulations: 10},
+	} {
+		_, _, err := runScenario(t, client, scenario)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: %v, want an invalid argument", scenario, err)
+		}
+	}
+}
+
+func TestSimulatorCancel(t *testing.T) {
+	client, server := startSimulator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Run(ctx, &simulation.Scenario{
+		Game:          "roulette",
+		Strategy:      "fib",
+		BankrollCents: 10_000_000,
+		WinGoalCents:  10_000_000,
+		MaxRounds:     5_000,
+		Simulations:   grpcMaxSimulations,
+		Seed:          1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("after cancelling got %v, want cancelled", err)
+	}
+
+	// The run stops with its client, so the server can stop gracefully soon after
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the run kept going after its client cancelled")
+	}
+}
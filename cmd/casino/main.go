@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// Exit codes shared by every command
+const (
+	exitOK    = 0 // The command ran to the end
+	exitError = 1 // The command failed while running
+	exitUsage = 2 // The command line or scenario file is invalid
+)
+
+// version is set at build time with -ldflags "-X main.version=v1.2.3"
+var version = ""
+
+// command is a subcommand of the casino binary, either run directly or
+// holding further subcommands
+type command struct {
+	name        string
+	summary     string
+	run         func(args []string) int
+	subcommands []command
+}
+
+// commands lists everything the casino binary can do
+var commands = []command{
+	{name: "baccarat", summary: "Play or simulate baccarat", subcommands: []command{
+		{name: "play", summary: "Play baccarat at an interactive table", run: baccaratPlay},
+		{name: "sim", summary: "Simulate the doubling strategy or Kelly sizing over many sessions", run: baccaratSim},
+		{name: "compare", summary: "Play several strategies on the same shoes and test their differences", run: baccaratCompare},
+		{name: "seats", summary: "Simulate several seats betting on one shared shoe", run: baccaratSeats},
+	}},
+	{name: "roulette", summary: "Play roulette or simulate its strategies", subcommands: []command{
+		{name: "play", summary: "Play roulette at an interactive table", run: roulettePlay},
+		{name: "sim", summary: "Simulate a betting strategy over many sessions", run: rouletteSim},
+		{name: "sweep", summary: "Simulate a strategy over a grid of settings with common random numbers", run: rouletteSweep},
+		{name: "compare", summary: "Play several strategies on the same spins and test their differences", run: rouletteCompare},
+		{name: "optimize", summary: "Search step tables, reset rules and bet coverage for the best strategy", run: rouletteOptimize},
+	}},
+	{name: "analyze", summary: "Analyze roulette strategies and wheels", subcommands: []command{
+		{name: "ruin", summary: "Solve the exact risk of ruin of a step progression", run: analyzeRuin},
+		{name: "bias", summary: "Test a spin log for a biased wheel and how to exploit it", run: analyzeBias},
+		{name: "variants", summary: "Report the house edge of every bet of each roulette variant", run: analyzeVariants},
+	}},
+	{name: "floor", summary: "Simulate a casino floor from the house's side: drop, hold and win per table", run: floorSim},
+	{name: "serve", summary: "Serve baccarat and roulette tables over an HTTP/JSON API", run: serve},
+	{name: "grpc", summary: "Run baccarat and roulette simulations for gRPC clients, streaming their progress", run: serveGRPC},
+	{name: "version", summary: "Print the version", run: printVersion},
+}
+
+// printUsage lists the subcommands of a command
+func printUsage(w io.Writer, path string, subcommands []command) {
+	fmt.Fprintf(w, "Usage: %s <command> [flags]\n\nCommands:\n", path)
+	for _, c := range subcommands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(w, "\nRun \"%s <command> -h\" for the flags of a command.\n", path)
+}
+
+// dispatch finds the subcommand named by the first argument and runs it
+func dispatch(path string, subcommands []command, args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stderr, path, subcommands)
+		return exitUsage
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout, path, subcommands)
+		return exitOK
+	}
+	for _, c := range subcommands {
+		if c.name != args[0] {
+			continue
+		}
+		if c.run != nil {
+			return c.run(args[1:])
+		}
+		return dispatch(path+" "+c.name, c.subcommands, args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", strings.TrimSpace(path+" "+args[0]))
+	printUsage(os.Stderr, path, subcommands)
+	return exitUsage
+}
+
+// newFlagSet returns the flag set of a command, with help text naming it
+func newFlagSet(name, summary string) *flag.FlagSet {
+	fs := flag.NewFlagSet("casino "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: casino %s [flags]\n\n%s.\n\nFlags:\n", name, summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the command line and the scenario file named by
+// -config, returning false with the exit code when the command should stop
+func parseFlags(fs *flag.FlagSet, args []string, config *string, game string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		return exitUsage, false
+	}
+	if config != nil {
+		if err := casino.ApplyConfig(fs, *config, game); err != nil {
+			return usageError(err), false
+		}
+	}
+	return exitOK, true
+}
+
+// fail reports an error that stopped a command while it ran
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	return exitError
+}
+
+// usageError reports an invalid flag or setting
+func usageError(err error) int {
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	return exitUsage
+}
+
+// stopped reports whether a stop channel is closed; a nil channel never is
+func stopped(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Calculate the standard deviation for a slice of integers
+func calculateStandardDeviation(data []int, mean float64) float64 {
+	var sumOfSquares float64
+	for _, value := range data {
+		sumOfSquares += math.Pow(float64(value)-mean, 2)
+	}
+	variance := sumOfSquares / float64(len(data))
+	return math.Sqrt(variance)
+}
+
+// printVersion prints the version of the binary and the Go release that built it
+func printVersion(args []string) int {
+	fs := newFlagSet("version", "Print the version")
+	if code, ok := parseFlags(fs, args, nil, ""); !ok {
+		return code
+	}
+	v := version
+	if info, ok := debug.ReadBuildInfo(); ok && v == "" {
+		v = info.Main.Version
+	}
+	if v == "" {
+		v = "(devel)"
+	}
+	fmt.Printf("casino %s %s/%s %s\n", v, runtime.GOOS, runtime.GOARCH, runtime.Version())
+	return exitOK
+}
+
+// Main function to run the command named on the command line
+func main() {
+	os.Exit(dispatch("casino", commands, os.Args[1:]))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExitCodes(t *testing.T) {
+	cases := []struct {
+		args string
+		want int
+	}{
+		{"", exitUsage},
+		{"help", exitOK},
+		{"poker", exitUsage},
+		{"roulette", exitUsage},
+		{"roulette spin", exitUsage},
+		{"version", exitOK},
+		{"floor -days 2", exitOK},
+		{"floor -days 0", exitUsage},
+		{"floor -arrivals -5", exitUsage},
+		{"floor -config missing.yaml", exitUsage},
+		{"serve extra", exitUsage},
+		{"grpc extra", exitUsage},
+		{"grpc -interval 0s", exitUsage},
+		{"baccarat play -h", exitOK},
+		{"baccarat play -once -decks 1", exitOK},
+		{"baccarat play extra", exitUsage},
+		{"baccarat seats -simulations 20 -maxhands 50", exitOK},
+		{"baccarat seats -simulations 20 -seat name=Ann -seat kelly=0.5;edge=0.01;variance=1", exitOK},
+		{"baccarat seats -simulations 20 -seats 15", exitUsage},
+		{"baccarat seats -simulations 20 -seat decks=6", exitUsage},
+		{"baccarat seats -simulations 20 -seat name=Ann -seat lossrebate=0.1;rolling=0.005", exitOK},
+		{"baccarat sim -simulations 20 -history= -lossrebate 0.1 -rolling 0.006", exitOK},
+		{"baccarat sim -simulations 20 -history= -lossrebate 1", exitUsage},
+		{"baccarat sim -simulations 20 -history= -rolling -0.01", exitUsage},
+		{"roulette sim -bogus", exitUsage},
+		{"roulette sim -strategy nope", exitUsage},
+		{"roulette sim -config missing.yaml", exitUsage},
+		{"roulette sim -simulations 10 -dashboard", exitOK},
+		{"roulette sim -strategy martingale -spinfile ../../Roulette/testdata/american.txt -walkforward -sessionspins 20", exitOK},
+		{"roulette sweep -simulations 10", exitUsage},
+		{"roulette sweep -simulations 10 -sweep bogus=1,2", exitUsage},
+		{"roulette sweep -simulations 10 -sweep bet=25,50", exitOK},
+		{"roulette compare -simulations 10 -arm strategy=fib", exitUsage},
+		{"roulette compare -simulations 10 -arm strategy=fib -arm seed=3", exitUsage},
+		{"roulette compare -simulations 10 -arm strategy=fib -arm strategy=martingale", exitOK},
+		{"baccarat compare -simulations 10 -arm bet=50 -arm kelly=0.5;edge=0.01;variance=1", exitOK},
+		{"roulette optimize -simulations 20 -population 4 -generations 2 -constraint maxbet<=500", exitOK},
+		{"roulette optimize -simulations 20 -maximize luck", exitUsage},
+		{"roulette optimize -simulations 20 -coverage dozen_4", exitUsage},
+		{"roulette play -h", exitOK},
+		{"roulette play -lapartage", exitUsage},
+		{"analyze ruin -strategy martingale", exitOK},
+		{"analyze ruin -strategy nope", exitUsage},
+		{"analyze ruin -bet 2.50 -balance 625 -profit 125", exitOK},
+		{"analyze ruin -steps 2.50,5,x", exitUsage},
+		{"roulette sim -simulations 10 -progression kelly -bets red,black", exitUsage},
+		{"analyze bias -n 500 -top 0", exitOK},
+		{"analyze bias -n 500 -top -1", exitUsage},
+		{"analyze bias -n 500 -sector 38", exitUsage},
+		{"analyze bias -n 500 -sector 37 -european", exitUsage},
+		{"analyze bias -n 500 -targets 0", exitUsage},
+		{"analyze bias -n 500 -seed 7 -bias 17:1.3 -exploit 5", exitOK},
+		{"analyze variants -variant mini", exitOK},
+		{"analyze bias -spinfile missing.txt", exitError},
+	}
+	for _, c := range cases {
+		if got := dispatch("casino", commands, strings.Fields(c.args)); got != c.want {
+			t.Errorf("casino %s: exit code %d, want %d", c.args, got, c.want)
+		}
+	}
+}
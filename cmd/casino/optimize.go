@@ -0,0 +1,589 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	roulette "github.com/BryceWayne/casino/Roulette"
+)
+
+// constraint bounds a metric of a candidate, such as maxbet<=500
+type constraint struct {
+	metric string
+	atMost bool
+	bound  float64
+}
+
+// constraintList collects the repeated -constraint flag
+type constraintList []constraint
+
+func (l *constraintList) String() string {
+	fields := make([]string, len(*l))
+	for i, c := range *l {
+		fields[i] = c.String()
+	}
+	return strings.Join(fields, " ")
+}
+
+func (l *constraintList) Set(value string) error {
+	c, err := parseConstraint(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, c)
+	return nil
+}
+
+func (c constraint) String() string {
+	op := ">="
+	if c.atMost {
+		op = "<="
+	}
+	return c.metric + op + strconv.FormatFloat(c.bound, 'f', -1, 64)
+}
+
+// optimizeMetrics are the metrics an objective or constraint can name:
+// those of a sweep, and maxbet, the largest single stake in dollars
+var optimizeMetrics = map[string]func(c *candidate, r sweepResult) float64{
+	"winrate": func(c *candidate, r sweepResult) float64 { return r.winRate },
+	"ev":      func(c *candidate, r sweepResult) float64 { return r.ev },
+	"lost":    func(c *candidate, r sweepResult) float64 { return r.lost },
+	"spins":   func(c *candidate, r sweepResult) float64 { return r.averageSpins },
+	"maxbet":  func(c *candidate, r sweepResult) float64 { return c.maxBet().Float() },
+}
+
+// parseConstraint parses "metric<=bound" or "metric>=bound", rates in percent
+func parseConstraint(text string) (constraint, error) {
+	for _, op := range []string{"<=", ">="} {
+		metric, bound, ok := strings.Cut(text, op)
+		if !ok {
+			continue
+		}
+		metric = strings.TrimSpace(metric)
+		if _, known := optimizeMetrics[metric]; !known {
+			return constraint{}, fmt.Errorf("unknown metric %q in constraint %q", metric, text)
+		}
+		value, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(bound), "$"), 64)
+		if err != nil {
+			return constraint{}, fmt.Errorf("invalid bound in constraint %q", text)
+		}
+		return constraint{metric: metric, atMost: op == "<=", bound: value}, nil
+	}
+	return constraint{}, fmt.Errorf("invalid constraint %q, want metric<=bound or metric>=bound", text)
+}
+
+// violation returns how far the candidate is outside the constraint, 0 when inside
+func (c constraint) violation(cand *candidate, r sweepResult) float64 {
+	value := optimizeMetrics[c.metric](cand, r)
+	if c.atMost {
+		return math.Max(0, value-c.bound)
+	}
+	return math.Max(0, c.bound-value)
+}
+
+// candidate is a strategy the optimizer tries: a step table, its reset
+// rules and the bets it covers
+type candidate struct {
+	steps      []casino.Money
+	resetOnWin bool
+	atEnd      string
+	bets       []string
+
+	train      sweepResult
+	validation sweepResult
+	score      float64 // Objective on the training seed, higher is better
+	violation  float64 // Total distance outside the constraints
+}
+
+// key identifies the candidate, so no strategy is simulated twice
+func (c *candidate) key() string {
+	return fmt.Sprintf("%v|%v|%s|%s", c.steps, c.resetOnWin, c.atEnd, strings.Join(c.bets, ","))
+}
+
+// maxBet returns the largest stake of the step table
+func (c *candidate) maxBet() casino.Money {
+	var largest casino.Money
+	for _, step := range c.steps {
+		if step > largest {
+			largest = step
+		}
+	}
+	return largest
+}
+
+// settings returns the flags that play the candidate
+func (c *candidate) settings() [][2]string {
+	steps := make([]string, len(c.steps))
+	for i, step := range c.steps {
+		steps[i] = strings.TrimPrefix(step.String(), "$")
+	}
+	return [][2]string{
+		{"progression", string(roulette.Steps)},
+		{"steps", strings.Join(steps, ",")},
+		{"resetonwin", strconv.FormatBool(c.resetOnWin)},
+		{"atend", c.atEnd},
+		{"bets", strings.Join(c.bets, ",")},
+	}
+}
+
+// String describes the candidate for the report
+func (c *candidate) String() string {
+	reset := "back one step on a win"
+	if c.resetOnWin {
+		reset = "reset on a win"
+	}
+	steps := make([]string, len(c.steps))
+	for i, step := range c.steps {
+		steps[i] = strings.TrimPrefix(step.String(), "$")
+	}
+	return fmt.Sprintf("steps %s, %s, %s at the end, on %s", strings.Join(steps, ","), reset, c.atEnd, strings.Join(c.bets, " + "))
+}
+
+// better reports whether a ranks above b: feasible candidates by their
+// score, infeasible ones below them by how far they miss the constraints
+func better(a, b *candidate) bool {
+	if (a.violation == 0) != (b.violation == 0) {
+		return a.violation == 0
+	}
+	if a.violation > 0 {
+		return a.violation < b.violation
+	}
+	return a.score > b.score
+}
+
+// searchSpace is what the optimizer may choose from
+type searchSpace struct {
+	unit     casino.Money
+	minSteps int
+	maxSteps int
+	coverage []string
+	maxBets  int
+}
+
+// endRules are the end-of-table rules a candidate may use
+var endRules = []string{string(roulette.RestartOnEnd), string(roulette.CapAtEnd), string(roulette.StopOnEnd)}
+
+// randomSteps draws a step table, each step a multiple of the unit between
+// one and four times the one before
+func (sp searchSpace) randomSteps(rng *rand.Rand) []casino.Money {
+	n := sp.minSteps + rng.Intn(sp.maxSteps-sp.minSteps+1)
+	steps := make([]casino.Money, n)
+	steps[0] = sp.unit * casino.Money(1+rng.Intn(2))
+	for i := 1; i < n; i++ {
+		steps[i] = steps[i-1].Scale(1 + 3*rng.Float64()).Ceil(sp.unit)
+	}
+	return steps
+}
+
+// randomBets draws between one and maxBets different bets from the coverage
+func (sp searchSpace) randomBets(rng *rand.Rand) []string {
+	most := sp.maxBets
+	if most > len(sp.coverage) {
+		most = len(sp.coverage)
+	}
+	n := 1 + rng.Intn(most)
+	bets := make([]string, 0, n)
+	for _, i := range rng.Perm(len(sp.coverage))[:n] {
+		bets = append(bets, sp.coverage[i])
+	}
+	sort.Strings(bets)
+	return bets
+}
+
+// random draws a candidate from the whole search space
+func (sp searchSpace) random(rng *rand.Rand) *candidate {
+	return &candidate{
+		steps:      sp.randomSteps(rng),
+		resetOnWin: rng.Intn(2) == 0,
+		atEnd:      endRules[rng.Intn(len(endRules))],
+		bets:       sp.randomBets(rng),
+	}
+}
+
+// crossover joins the start of one parent's step table to the end of the
+// other's and takes each rule and the bets from either parent
+func (sp searchSpace) crossover(rng *rand.Rand, a, b *candidate) *candidate {
+	cut := 1 + rng.Intn(len(a.steps))
+	steps := append([]casino.Money{}, a.steps[:cut]...)
+	if cut < len(b.steps) {
+		steps = append(steps, b.steps[cut:]...)
+	}
+	if len(steps) > sp.maxSteps {
+		steps = steps[:sp.maxSteps]
+	}
+	for len(steps) < sp.minSteps {
+		steps = append(steps, steps[len(steps)-1].Scale(2).Ceil(sp.unit))
+	}
+	child := &candidate{steps: steps, resetOnWin: a.resetOnWin, atEnd: a.atEnd, bets: a.bets}
+	if rng.Intn(2) == 0 {
+		child.resetOnWin = b.resetOnWin
+	}
+	if rng.Intn(2) == 0 {
+		child.atEnd = b.atEnd
+	}
+	if rng.Intn(2) == 0 {
+		child.bets = b.bets
+	}
+	return child
+}
+
+// mutate changes one thing about the candidate: a step, the length of the
+// table, a rule or a bet
+func (sp searchSpace) mutate(rng *rand.Rand, c *candidate) *candidate {
+	m := &candidate{steps: append([]casino.Money{}, c.steps...), resetOnWin: c.resetOnWin, atEnd: c.atEnd, bets: append([]string{}, c.bets...)}
+	switch rng.Intn(5) {
+	case 0:
+		i := rng.Intn(len(m.steps))
+		m.steps[i] = m.steps[i].Scale(0.5 + 1.5*rng.Float64()).Ceil(sp.unit)
+		if m.steps[i] < sp.unit {
+			m.steps[i] = sp.unit
+		}
+	case 1:
+		if len(m.steps) < sp.maxSteps && (rng.Intn(2) == 0 || len(m.steps) == sp.minSteps) {
+			m.steps = append(m.steps, m.steps[len(m.steps)-1].Scale(1+2*rng.Float64()).Ceil(sp.unit))
+		} else if len(m.steps) > sp.minSteps {
+			m.steps = m.steps[:len(m.steps)-1]
+		}
+	case 2:
+		m.resetOnWin = !m.resetOnWin
+	case 3:
+		m.atEnd = endRules[rng.Intn(len(endRules))]
+	default:
+		m.bets = sp.randomBets(rng)
+	}
+	return m
+}
+
+// optimizer evaluates candidates with the simulator, on a training seed
+// for the search and a held-out seed to check the winners
+type optimizer struct {
+	args           []string
+	maximize       bool
+	objective      string
+	constraints    []constraint
+	numSimulations int
+	trainSeed      int64
+	validationSeed int64
+	seen           map[string]*candidate
+	evaluations    int
+}
+
+// build plays the candidate's settings over the command line
+func (o *optimizer) build(c *candidate) (*rouletteSetup, error) {
+	fs, f, _ := optimizeFlags()
+	if code, ok := parseFlags(fs, o.args, f.config, "roulette"); !ok {
+		return nil, fmt.Errorf("invalid command line (exit code %d)", code)
+	}
+	for _, setting := range c.settings() {
+		if err := fs.Set(setting[0], setting[1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyStrategy(fs, *f.strategy); err != nil {
+		return nil, err
+	}
+	return f.setup()
+}
+
+// evaluate simulates the candidate on the training seed, unless an equal
+// candidate was already simulated, and scores it
+func (o *optimizer) evaluate(c *candidate) (*candidate, error) {
+	if seen, ok := o.seen[c.key()]; ok {
+		return seen, nil
+	}
+	o.seen[c.key()] = c
+
+	// A bet limit needs no simulation to be broken
+	for _, con := range o.constraints {
+		if con.metric == "maxbet" {
+			c.violation += con.violation(c, sweepResult{})
+		}
+	}
+	if c.violation > 0 {
+		return c, nil
+	}
+
+	setup, err := o.build(c)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := setup.runSessions(setup.progressions, o.numSimulations, o.trainSeed, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.train = summarize(setup, sessions)
+	o.evaluations++
+	for _, con := range o.constraints {
+		c.violation += con.violation(c, c.train)
+	}
+	c.score = o.value(c, c.train)
+	return c, nil
+}
+
+// value returns the objective of a result, turned so that higher is better
+func (o *optimizer) value(c *candidate, r sweepResult) float64 {
+	v := optimizeMetrics[o.objective](c, r)
+	if !o.maximize {
+		v = -v
+	}
+	return v
+}
+
+// validate simulates the candidate on the held-out seed
+func (o *optimizer) validate(c *candidate) error {
+	setup, err := o.build(c)
+	if err != nil {
+		return err
+	}
+	sessions, err := setup.runSessions(setup.progressions, o.numSimulations, o.validationSeed, nil)
+	if err != nil {
+		return err
+	}
+	c.validation = summarize(setup, sessions)
+	return nil
+}
+
+// heldOutSeed returns the given validation seed, or a random one when it is
+// 0, never the training seed, so the winners are checked on spins the
+// search has not seen
+func heldOutSeed(trainSeed, validationSeed int64) int64 {
+	for validationSeed == 0 || validationSeed == trainSeed {
+		validationSeed = time.Now().UnixNano() ^ 0x5deece66d
+	}
+	return validationSeed
+}
+
+// search evolves a population of size candidates, starting from first,
+// for the given number of generations, reporting each generation after
+// the first. The random method draws every new candidate afresh; the
+// genetic one breeds them from the best of the last generation.
+func (o *optimizer) search(rng *rand.Rand, space searchSpace, method string, first *candidate, size, generations int, report func(generation int, population []*candidate)) error {
+	population := []*candidate{first}
+	for len(population) < size {
+		c, err := o.evaluate(space.random(rng))
+		if err != nil {
+			return err
+		}
+		population = append(population, c)
+	}
+
+	for g := 1; g < generations; g++ {
+		next := make([]*candidate, 0, size)
+		if method == "random" {
+			next = append(next, population...)
+			for len(next) < len(population)*2 {
+				c, err := o.evaluate(space.random(rng))
+				if err != nil {
+					return err
+				}
+				next = append(next, c)
+			}
+			sort.SliceStable(next, func(i, j int) bool { return better(next[i], next[j]) })
+			population = next[:size]
+		} else {
+			// Keep the two best, then breed the rest from tournaments of three
+			sort.SliceStable(population, func(i, j int) bool { return better(population[i], population[j]) })
+			next = append(next, population[:2]...)
+			pick := func() *candidate {
+				best := population[rng.Intn(len(population))]
+				for k := 0; k < 2; k++ {
+					if c := population[rng.Intn(len(population))]; better(c, best) {
+						best = c
+					}
+				}
+				return best
+			}
+			for len(next) < size {
+				child := space.crossover(rng, pick(), pick())
+				if rng.Float64() < 0.5 {
+					child = space.mutate(rng, child)
+				}
+				c, err := o.evaluate(child)
+				if err != nil {
+					return err
+				}
+				next = append(next, c)
+			}
+			population = next
+		}
+		if report != nil {
+			report(g+1, population)
+		}
+	}
+	return nil
+}
+
+// ranked returns up to top of the distinct candidates that meet the
+// constraints, best first on the training seed
+func (o *optimizer) ranked(top int) []*candidate {
+	var ranked []*candidate
+	for _, c := range o.seen {
+		if c.violation == 0 {
+			ranked = append(ranked, c)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].key() < ranked[j].key()
+	})
+	if len(ranked) > top {
+		ranked = ranked[:top]
+	}
+	return ranked
+}
+
+// optimizeFlags defines the flags of the optimize command
+func optimizeFlags() (*flag.FlagSet, *rouletteFlags, *optimizeSettings) {
+	fs := newFlagSet("roulette optimize", "Search step tables, reset rules and bet coverage for the best strategy")
+	f := defineRouletteFlags(fs)
+	*f.numSimulations = 2_000
+	fs.Lookup("simulations").DefValue = "2000"
+	o := &optimizeSettings{constraints: &constraintList{}}
+	o.method = fs.String("method", "genetic", "Search method (random or genetic)")
+	o.maximize = fs.String("maximize", "winrate", "Metric to maximize (winrate, ev, lost, spins or maxbet)")
+	o.minimize = fs.String("minimize", "", "Metric to minimize instead of maximizing one")
+	fs.Var(o.constraints, "constraint", "Constraint such as maxbet<=500 or lost<=20, rates in percent (repeat for more)")
+	o.population = fs.Int("population", 24, "Candidates in each generation of the genetic search")
+	o.generations = fs.Int("generations", 15, "Generations of the genetic search; the random search tries population times generations candidates")
+	o.stepLengths = fs.String("steplengths", "3:8", "Shortest and longest step table, as min:max")
+	o.coverage = fs.String("coverage", "red,black,odd,even,low,high,dozen 1,dozen 2,dozen 3,column 1,column 2,column 3", "Bets a candidate may cover")
+	o.maxBets = fs.Int("maxbets", 2, "Most bets a candidate covers at once")
+	o.validationSeed = fs.Int64("validationseed", 0, "Held-out seed the best candidates are checked on (0 for a random seed)")
+	o.top = fs.Int("top", 5, "Best candidates to check on the held-out seed")
+	return fs, f, o
+}
+
+// optimizeSettings are the flags of the search itself
+type optimizeSettings struct {
+	method         *string
+	maximize       *string
+	minimize       *string
+	constraints    *constraintList
+	population     *int
+	generations    *int
+	stepLengths    *string
+	coverage       *string
+	maxBets        *int
+	validationSeed *int64
+	top            *int
+}
+
+// rouletteOptimize searches step tables, reset rules and bet coverage for
+// the strategy that does best on the objective within the constraints.
+// Every candidate plays the same spins, and the winners are replayed on a
+// held-out seed: how much worse they do there is how much of their lead
+// was luck the search fitted to.
+func rouletteOptimize(args []string) int {
+	fs, f, settings := optimizeFlags()
+	if code, ok := parseFlags(fs, args, f.config, "roulette"); !ok {
+		return code
+	}
+	if err := applyStrategy(fs, *f.strategy); err != nil {
+		return usageError(err)
+	}
+
+	o := &optimizer{args: args, maximize: true, objective: *settings.maximize, constraints: *settings.constraints, numSimulations: *f.numSimulations, seen: map[string]*candidate{}}
+	if *settings.minimize != "" {
+		o.maximize, o.objective = false, *settings.minimize
+	}
+	if _, ok := optimizeMetrics[o.objective]; !ok {
+		return usageError(fmt.Errorf("unknown objective %q", o.objective))
+	}
+	if *settings.method != "random" && *settings.method != "genetic" {
+		return usageError(fmt.Errorf("unknown search method %q", *settings.method))
+	}
+	if *settings.population < 2 || *settings.generations < 1 || *settings.top < 1 || *f.numSimulations < 1 {
+		return usageError(fmt.Errorf("the population needs at least 2 candidates, and the generations, -top and -simulations at least 1"))
+	}
+
+	space := searchSpace{unit: *f.unitBet, maxBets: *settings.maxBets}
+	if _, err := fmt.Sscanf(*settings.stepLengths, "%d:%d", &space.minSteps, &space.maxSteps); err != nil || space.minSteps < 1 || space.maxSteps < space.minSteps {
+		return usageError(fmt.Errorf("invalid step lengths %q, want min:max", *settings.stepLengths))
+	}
+	for _, bet := range strings.Split(*settings.coverage, ",") {
+		if _, err := roulette.ParseBet(strings.TrimSpace(bet), roulette.Classic{European: *f.european}); err != nil {
+			return usageError(err)
+		}
+		space.coverage = append(space.coverage, strings.TrimSpace(bet))
+	}
+	if space.unit <= 0 || space.maxBets < 1 {
+		return usageError(fmt.Errorf("the unit bet and -maxbets must be positive"))
+	}
+
+	o.trainSeed = compareSeed(*f.seed)
+	o.validationSeed = heldOutSeed(o.trainSeed, *settings.validationSeed)
+	rng := casino.NewRand(o.trainSeed)
+
+	// A candidate that cannot be built is a bad command line, not a bad strategy
+	first, err := o.evaluate(space.random(rng))
+	if err != nil {
+		return usageError(err)
+	}
+	direction := "Maximizing"
+	if !o.maximize {
+		direction = "Minimizing"
+	}
+	fmt.Printf("%s %s with %d simulations per candidate, training seed %d, validation seed %d\n", direction, o.objective, o.numSimulations, o.trainSeed, o.validationSeed)
+	for _, c := range o.constraints {
+		fmt.Printf("Subject to %s\n", c)
+	}
+
+	report := func(generation int, population []*candidate) {
+		best := population[0]
+		for _, c := range population {
+			if better(c, best) {
+				best = c
+			}
+		}
+		if best.violation > 0 {
+			fmt.Printf("Generation %d: no candidate meets the constraints yet (%d simulated)\n", generation, o.evaluations)
+			return
+		}
+		fmt.Printf("Generation %d: best %s %.2f (%d simulated)\n", generation, o.objective, optimizeMetrics[o.objective](best, best.train), o.evaluations)
+	}
+	if err := o.search(rng, space, *settings.method, first, *settings.population, *settings.generations, report); err != nil {
+		return fail(err)
+	}
+
+	// Replay the best distinct candidates on the held-out seed
+	ranked := o.ranked(*settings.top)
+	if len(ranked) == 0 {
+		fmt.Println("\nNo candidate meets the constraints.")
+		return exitOK
+	}
+	fmt.Printf("\nBest %d candidates, on the training seed and the held-out validation seed:\n", len(ranked))
+	gap := 0.0
+	for i, c := range ranked {
+		if err := o.validate(c); err != nil {
+			return fail(err)
+		}
+		train, validation := optimizeMetrics[o.objective](c, c.train), optimizeMetrics[o.objective](c, c.validation)
+		gap += o.value(c, c.train) - o.value(c, c.validation)
+		fmt.Printf("\n%d. %s\n", i+1, c)
+		fmt.Printf("   Training:   win rate %.2f%%, EV/session %.2f, lost %.2f%%, avg spins %.2f\n", c.train.winRate, c.train.ev, c.train.lost, c.train.averageSpins)
+		fmt.Printf("   Validation: win rate %.2f%%, EV/session %.2f, lost %.2f%%, avg spins %.2f\n", c.validation.winRate, c.validation.ev, c.validation.lost, c.validation.averageSpins)
+		fmt.Printf("   %s: %.2f training, %.2f validation\n", o.objective, train, validation)
+		for _, con := range o.constraints {
+			if con.violation(c, c.validation) > 0 {
+				fmt.Printf("   Breaks %s on the validation seed\n", con)
+			}
+		}
+	}
+	if gap /= float64(len(ranked)); gap > 0 {
+		fmt.Printf("\nThe training seed flatters these candidates by %.2f %s on average; that much of their lead is fitted noise.\n", gap, o.objective)
+	} else {
+		fmt.Printf("\nThese candidates do as well on the held-out seed, so the search has not fitted the training spins.\n")
+	}
+	fmt.Printf("\nBest strategy: -progression steps -steps %s -resetonwin=%v -atend %s -bets %q\n",
+		ranked[0].settings()[1][1], ranked[0].resetOnWin, ranked[0].atEnd, strings.Join(ranked[0].bets, ","))
+	return exitOK
+}
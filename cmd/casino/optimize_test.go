@@ -0,0 +1,182 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+func TestParseConstraint(t *testing.T) {
+	c, err := parseConstraint("maxbet<=$500")
+	if err != nil || c != (constraint{metric: "maxbet", atMost: true, bound: 500}) {
+		t.Fatalf("parseConstraint = %+v, %v", c, err)
+	}
+	cand := &candidate{steps: []casino.Money{casino.Dollars(100), casino.Dollars(650)}}
+	if v := c.violation(cand, sweepResult{}); v != 150 {
+		t.Errorf("violation of maxbet<=500 by a $650 step = %v, want 150", v)
+	}
+	if c, err := parseConstraint("winrate>=80"); err != nil || c.violation(cand, sweepResult{winRate: 85}) != 0 {
+		t.Errorf("winrate>=80 = %+v, %v", c, err)
+	}
+	for _, text := range []string{"maxbet<500", "luck<=1", "lost<=x"} {
+		if _, err := parseConstraint(text); err == nil {
+			t.Errorf("parseConstraint(%q) accepted", text)
+		}
+	}
+}
+
+func TestBetter(t *testing.T) {
+	feasible := func(score float64) *candidate { return &candidate{score: score} }
+	infeasible := func(violation float64) *candidate { return &candidate{score: 100, violation: violation} }
+	cases := []struct {
+		name string
+		a, b *candidate
+		want bool
+	}{
+		{"higher score", feasible(2), feasible(1), true},
+		{"lower score", feasible(1), feasible(2), false},
+		{"equal scores", feasible(1), feasible(1), false},
+		{"feasible over infeasible", feasible(-5), infeasible(0.1), true},
+		{"infeasible under feasible", infeasible(0.1), feasible(-5), false},
+		{"smaller violation", infeasible(1), infeasible(2), true},
+		{"larger violation", infeasible(2), infeasible(1), false},
+	}
+	for _, c := range cases {
+		if got := better(c.a, c.b); got != c.want {
+			t.Errorf("%s: better = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSearchSpaceStepLengths(t *testing.T) {
+	space := searchSpace{unit: casino.Dollars(10), minSteps: 3, maxSteps: 5, coverage: []string{"red", "black", "dozen 1"}, maxBets: 2}
+	rng := casino.NewRand(1)
+	check := func(how string, c *candidate) {
+		t.Helper()
+		if len(c.steps) < space.minSteps || len(c.steps) > space.maxSteps {
+			t.Fatalf("%s gave %d steps %v, want %d to %d", how, len(c.steps), c.steps, space.minSteps, space.maxSteps)
+		}
+		for _, step := range c.steps {
+			if step < space.unit || step%space.unit != 0 {
+				t.Fatalf("%s gave step %s, want a multiple of %s", how, step, space.unit)
+			}
+		}
+		if len(c.bets) < 1 || len(c.bets) > space.maxBets {
+			t.Fatalf("%s gave bets %v, want 1 to %d", how, c.bets, space.maxBets)
+		}
+	}
+
+	// Parents at both ends of the range breed and mutate within it
+	short := &candidate{steps: []casino.Money{1000, 2000, 4000}, atEnd: "cap", bets: []string{"red"}}
+	long := &candidate{steps: []casino.Money{1000, 2000, 4000, 8000, 16000}, atEnd: "stop", bets: []string{"black", "red"}}
+	for i := 0; i < 2_000; i++ {
+		a, b := short, long
+		if i%2 == 0 {
+			a, b = long, short
+		}
+		child := space.crossover(rng, a, b)
+		check("crossover", child)
+		check("mutate", space.mutate(rng, child))
+		check("random", space.random(rng))
+	}
+}
+
+// testOptimizer scores candidates on their win rate over 50 sessions
+func testOptimizer() *optimizer {
+	return &optimizer{args: []string{"-simulations", "50"}, maximize: true, objective: "winrate", numSimulations: 50, trainSeed: 7, validationSeed: 8, seen: map[string]*candidate{}}
+}
+
+func TestEvaluateDeduplicates(t *testing.T) {
+	o := testOptimizer()
+	c := &candidate{steps: []casino.Money{casino.Dollars(100), casino.Dollars(200)}, atEnd: "cap", bets: []string{"red"}}
+	first, err := o.evaluate(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	same := &candidate{steps: []casino.Money{casino.Dollars(100), casino.Dollars(200)}, atEnd: "cap", bets: []string{"red"}}
+	again, err := o.evaluate(same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != first || o.evaluations != 1 || len(o.seen) != 1 {
+		t.Errorf("an equal candidate was simulated again: %d evaluations, %d seen", o.evaluations, len(o.seen))
+	}
+	other := &candidate{steps: []casino.Money{casino.Dollars(100), casino.Dollars(200)}, resetOnWin: true, atEnd: "cap", bets: []string{"red"}}
+	if _, err := o.evaluate(other); err != nil || o.evaluations != 2 {
+		t.Errorf("a different candidate was not simulated: %d evaluations, %v", o.evaluations, err)
+	}
+}
+
+func TestValidationSeed(t *testing.T) {
+	if seed := heldOutSeed(7, 8); seed != 8 {
+		t.Errorf("heldOutSeed(7, 8) = %d, want the given seed", seed)
+	}
+	for _, given := range []int64{0, 7} {
+		if seed := heldOutSeed(7, given); seed == 0 || seed == 7 {
+			t.Errorf("heldOutSeed(7, %d) = %d, want a seed other than the training seed", given, seed)
+		}
+	}
+
+	// The held-out seed plays other sessions than the training seed, and
+	// the training seed replays its own
+	o := testOptimizer()
+	c, err := o.evaluate(&candidate{steps: []casino.Money{casino.Dollars(100), casino.Dollars(300), casino.Dollars(900)}, atEnd: "cap", bets: []string{"dozen 1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.validate(c); err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(c.validation, c.train) {
+		t.Errorf("validation on seed 8 matched training on seed 7: %+v", c.train)
+	}
+	o.validationSeed = o.trainSeed
+	if err := o.validate(c); err != nil || !reflect.DeepEqual(c.validation, c.train) {
+		t.Errorf("validation on the training seed = %+v, want %+v", c.validation, c.train)
+	}
+}
+
+func TestOptimizeSearch(t *testing.T) {
+	space := searchSpace{unit: casino.Dollars(100), minSteps: 2, maxSteps: 3, coverage: []string{"red", "dozen 1", "column 2"}, maxBets: 1}
+	run := func(method string) []string {
+		o := testOptimizer()
+		rng := casino.NewRand(o.trainSeed)
+		first, err := o.evaluate(space.random(rng))
+		if err != nil {
+			t.Fatal(err)
+		}
+		generations := 0
+		report := func(generation int, population []*candidate) {
+			generations++
+			if len(population) != 6 || generation != generations+1 {
+				t.Fatalf("generation %d has %d candidates, want 6", generation, len(population))
+			}
+		}
+		if err := o.search(rng, space, method, first, 6, 3, report); err != nil {
+			t.Fatal(err)
+		}
+		if generations != 2 {
+			t.Errorf("%d generations reported, want 2", generations)
+		}
+		var keys []string
+		ranked := o.ranked(3)
+		for i, c := range ranked {
+			if i > 0 && c.score > ranked[i-1].score {
+				t.Errorf("%s ranked %s above %s", method, ranked[i-1], c)
+			}
+			keys = append(keys, c.key())
+		}
+		return keys
+	}
+
+	// Seed 7 breeds and ranks the same candidates every time
+	for method, want := range map[string][]string{
+		"genetic": {"[$200 $600 $2300]|true|restart|column 2", "[$100 $800 $3100]|false|restart|red", "[$200 $600 $2300]|true|cap|column 2"},
+		"random":  {"[$200 $600 $2300]|true|restart|column 2", "[$100 $400 $1600]|false|restart|dozen 1", "[$200 $800 $3100]|true|cap|column 2"},
+	} {
+		if got := run(method); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s search ranked %v, want %v", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,521 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	roulette "github.com/BryceWayne/casino/Roulette"
+	"github.com/cheggaaa/pb/v3"
+)
+
+// strategies are the flag values each roulette strategy starts from: fib
+// follows a Fibonacci progression on the third dozen, martingale a step
+// table on the high numbers and the third dozen, and mixed bets a flat
+// amount on the high numbers, the third dozen and two streets
+var strategies = map[string]map[string]string{
+	"fib": {
+		"bets": "dozen 3", "progression": "fibonacci", "bet": "100", "maxsteps": "7",
+		"balance": "25000", "profit": "5000",
+	},
+	"martingale": {
+		"bets": "high,dozen 3", "progression": "steps", "steps": "25,50,150,450,850", "bet": "25", "maxsteps": "5",
+		"balance": "10000", "profit": "1000",
+	},
+	"mixed": {
+		"bets": "high:100,dozen 3:100,street 7-8-9:25,street 10-11-12:25", "progression": "flat",
+		"balance": "10000", "profit": "1000",
+	},
+}
+
+// applyStrategy sets the strategy's values on every flag not already given
+// on the command line or by the scenario file
+func applyStrategy(fs *flag.FlagSet, strategy string) error {
+	values, ok := strategies[strategy]
+	if !ok {
+		return fmt.Errorf("unknown strategy %q", strategy)
+	}
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for name, value := range values {
+		if !given[name] {
+			if err := fs.Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rouletteFlags are the settings of a roulette simulation, shared by the
+// commands that run one
+type rouletteFlags struct {
+	config         *string
+	strategy       *string
+	initialBalance *casino.Money
+	unitBet        *casino.Money
+	profitGoal     *casino.Money
+	stopLoss       *casino.Money
+	maxSpins       *int
+	numSimulations *int
+	seed           *int64
+	european       *bool
+	laPartage      *bool
+	betList        *string
+	bias           *string
+	physics        *string
+	system         *string
+	steps          *string
+	maxSteps       *int
+	resetOnWin     *bool
+	atEnd          *string
+	kellyFraction  *float64
+	kellyCap       *float64
+	edge           *float64
+	variance       *float64
+	estimateSpins  *int
+	insideMin      *casino.Money
+	insideMax      *casino.Money
+	outsideMin     *casino.Money
+	outsideMax     *casino.Money
+	tableMin       *casino.Money
+	tableMax       *casino.Money
+	atLimitRule    *string
+	chipList       *string
+	chipUnit       *casino.Money
+	rounding       *string
+}
+
+// defineRouletteFlags defines the flags of a roulette simulation
+func defineRouletteFlags(fs *flag.FlagSet) *rouletteFlags {
+	return &rouletteFlags{
+		config:         fs.String("config", "", "Scenario file (YAML or JSON) with the settings; flags override it"),
+		strategy:       fs.String("strategy", "fib", "Strategy (fib, martingale or mixed) setting the defaults of -bets, -progression, -steps, -bet, -maxsteps, -balance and -profit"),
+		initialBalance: casino.MoneyFlag(fs, "balance", casino.Dollars(25_000), "Initial balance"),
+		unitBet:        casino.MoneyFlag(fs, "bet", casino.Dollars(100), "Unit bet amount"),
+		profitGoal:     casino.MoneyFlag(fs, "profit", casino.Dollars(5_000), "Profit goal"),
+		stopLoss:       casino.MoneyFlag(fs, "stoploss", 0, "Stop loss: quit when the balance falls below it"),
+		maxSpins:       fs.Int("maxspins", 0, "Spins after which a session stops (0 for no cap)"),
+		numSimulations: fs.Int("simulations", 1_000_000, "Number of simulations to run"),
+		seed:           fs.Int64("seed", 0, "Seed of the spins; runs with the same seed see the same spins (0 for a random seed)"),
+		european:       fs.Bool("european", false, "Use European wheel (single 0)"),
+		laPartage:      fs.Bool("lapartage", false, "Return half of a losing even-money bet on zero (European wheel only)"),
+		betList:        fs.String("bets", "dozen 3", "Comma separated bets such as \"dozen 3,street 7-8-9:25\"; an amount sets that bet's unit"),
+		bias:           fs.String("bias", "", "Biased pocket weights such as 17:1.5,32:1.2 (default: fair wheel)"),
+		physics:        fs.String("physics", "", "Spin with the ball and rotor physics model, \"default\" or settings such as ball=2.2,tilt=0.02,signature"),
+		system:         fs.String("progression", "fibonacci", "Progression system (flat, steps, martingale, fibonacci, dalembert, labouchere, oscars_grind, paroli, kelly)"),
+		steps:          fs.String("steps", "", "Comma separated step table for the steps system, or the Labouchère line"),
+		maxSteps:       fs.Int("maxsteps", 7, "Number of steps in the progression"),
+		resetOnWin:     fs.Bool("resetonwin", true, "Return to the first step on any win"),
+		atEnd:          fs.String("atend", "restart", "What to do after the last step (restart, cap or stop)"),
+		kellyFraction:  fs.Float64("kelly", 1, "Share of the full Kelly stake for the kelly system"),
+		kellyCap:       fs.Float64("kellycap", 0, "Largest share of the balance the kelly system stakes (0 for no cap)"),
+		edge:           fs.Float64("edge", 0, "Edge per unit staked for the kelly system (0 to work it out from the wheel)"),
+		variance:       fs.Float64("variance", 0, "Variance per unit staked for the kelly system (0 to work it out from the wheel)"),
+		estimateSpins:  fs.Int("estimate", 100_000, "Spins used to estimate the pocket odds of the physics model for the kelly system"),
+		insideMin:      casino.MoneyFlag(fs, "insidemin", 0, "Table minimum for each inside bet"),
+		insideMax:      casino.MoneyFlag(fs, "insidemax", 0, "Table maximum for each inside bet (0 for no limit)"),
+		outsideMin:     casino.MoneyFlag(fs, "outsidemin", 0, "Table minimum for each outside bet"),
+		outsideMax:     casino.MoneyFlag(fs, "outsidemax", 0, "Table maximum for each outside bet (0 for no limit)"),
+		tableMin:       casino.MoneyFlag(fs, "tablemin", 0, "Table minimum for the total staked on a spin"),
+		tableMax:       casino.MoneyFlag(fs, "tablemax", 0, "Table maximum for the total staked on a spin (0 for no limit)"),
+		atLimitRule:    fs.String("atlimit", "cap", "What a progression does at a table limit (cap, quit or restart)"),
+		chipList:       fs.String("chips", "", "Comma separated chip denominations such as 1,5,25,100 (default: bet exact amounts)"),
+		chipUnit:       casino.MoneyFlag(fs, "chipunit", 0, "Minimum bet unit (default: the smallest chip)"),
+		rounding:       fs.String("rounding", "up", "What to do with a stake the chips cannot make (up, down or refuse)"),
+	}
+}
+
+// parse parses the command line, the scenario file and the strategy,
+// returning false with the exit code when the command should stop
+func (f *rouletteFlags) parse(fs *flag.FlagSet, args []string) (int, bool) {
+	if code, ok := parseFlags(fs, args, f.config, "roulette"); !ok {
+		return code, false
+	}
+	if err := applyStrategy(fs, *f.strategy); err != nil {
+		return usageError(err), false
+	}
+	return exitOK, true
+}
+
+// rouletteSetup is a roulette simulation ready to run
+type rouletteSetup struct {
+	variant        roulette.Classic
+	wheel          *roulette.Wheel
+	source         roulette.SpinSource
+	bets           []roulette.Bet
+	progressions   []roulette.ProgressionConfig
+	limits         roulette.TableLimits
+	atLimit        roulette.LimitRule
+	initialBalance casino.Money
+	profitGoal     casino.Money
+	stopLoss       casino.Money
+	maxSpins       int
+	record         func(balance casino.Money, won bool, rounds int) // Called as each session finishes, when set
+	stop           <-chan struct{}                                  // Closed to skip the sessions not yet started, when set
+}
+
+// setup builds the wheel, bets, progressions and table limits from the
+// flags, returning an error when a setting is invalid
+func (f *rouletteFlags) setup() (*rouletteSetup, error) {
+	progression := roulette.ProgressionConfig{
+		System:     roulette.System(*f.system),
+		Unit:       *f.unitBet,
+		MaxSteps:   *f.maxSteps,
+		ResetOnWin: *f.resetOnWin,
+		AtEnd:      roulette.EndRule(*f.atEnd),
+		Sizing:     casino.Kelly{Edge: *f.edge, Variance: *f.variance, Fraction: *f.kellyFraction, Cap: *f.kellyCap},
+	}
+	if *f.steps != "" {
+		var err error
+		if progression.Steps, err = roulette.ParseSteps(*f.steps); err != nil {
+			return nil, err
+		}
+	}
+	var chips casino.Chips
+	if *f.chipList != "" {
+		var err error
+		if chips, err = casino.ParseChips(*f.chipList); err != nil {
+			return nil, err
+		}
+		chips.Unit = *f.chipUnit
+		if err = chips.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	chipRule, err := casino.ParseChipRule(*f.rounding)
+	if err != nil {
+		return nil, err
+	}
+	progression.Chips, progression.Rounding = chips, chipRule
+	limits := roulette.TableLimits{
+		Inside:  roulette.Limit{Min: *f.insideMin, Max: *f.insideMax},
+		Outside: roulette.Limit{Min: *f.outsideMin, Max: *f.outsideMax},
+		Total:   roulette.Limit{Min: *f.tableMin, Max: *f.tableMax},
+	}
+	for _, limit := range []roulette.Limit{limits.Inside, limits.Outside, limits.Total} {
+		if limit.Min < 0 || limit.Max < 0 {
+			return nil, fmt.Errorf("table limits cannot be negative, as %s to %s is", limit.Min, limit.Max)
+		}
+	}
+	if *f.initialBalance <= 0 {
+		return nil, fmt.Errorf("balance must be positive, not %s", *f.initialBalance)
+	}
+	if *f.unitBet <= 0 {
+		return nil, fmt.Errorf("bet unit must be positive, not %s", *f.unitBet)
+	}
+	atLimit, err := roulette.ParseLimitRule(*f.atLimitRule)
+	if err != nil {
+		return nil, err
+	}
+	var weights []float64
+	if *f.bias != "" {
+		if weights, err = roulette.ParseBias(*f.bias, *f.european); err != nil {
+			return nil, err
+		}
+	}
+	wheel, err := roulette.NewWheel(*f.european, weights)
+	if err != nil {
+		return nil, err
+	}
+	var source roulette.SpinSource = wheel
+	if *f.laPartage && !*f.european {
+		return nil, errors.New("la partage needs a European wheel")
+	}
+	variant := roulette.Classic{European: *f.european, LaPartage: *f.laPartage}
+	bets, err := roulette.ParseBets(*f.betList, variant)
+	if err != nil {
+		return nil, err
+	}
+	if *f.physics != "" {
+		if source, err = roulette.ParsePhysics(*f.physics, *f.european); err != nil {
+			return nil, err
+		}
+	}
+
+	// Every bet follows the progression, from its own unit when the layout gives one
+	progressions := make([]roulette.ProgressionConfig, len(bets))
+	for i, bet := range bets {
+		progressions[i] = progression
+		if bet.Amount > 0 {
+			progressions[i].Unit = bet.Amount
+		}
+	}
+
+	// Size the Kelly bet from the odds of the wheel. Each bet would take its
+	// Kelly share of the whole balance, so a layout of several would stake
+	// more between them than Kelly allows.
+	if progression.System == roulette.Kelly {
+		if len(bets) > 1 {
+			return nil, fmt.Errorf("the kelly system sizes a single bet, not a layout of %d", len(bets))
+		}
+		probabilities := wheel.Probabilities()
+		if *f.physics != "" {
+			probabilities = roulette.EstimateProbabilities(source, roulette.Pockets(*f.european), *f.estimateSpins)
+		}
+		progressions[0].Sizing = roulette.KellyFor(variant, bets[0], probabilities, progression.Sizing)
+	}
+	for _, cfg := range progressions {
+		if _, err := roulette.NewProgression(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return &rouletteSetup{
+		variant:        variant,
+		wheel:          wheel,
+		source:         source,
+		bets:           bets,
+		progressions:   progressions,
+		limits:         limits,
+		atLimit:        atLimit,
+		initialBalance: *f.initialBalance,
+		profitGoal:     *f.profitGoal,
+		stopLoss:       *f.stopLoss,
+		maxSpins:       *f.maxSpins,
+	}, nil
+}
+
+// runSessions runs the sessions on one worker per CPU, each session
+// spinning from a generator seeded from the run's seed, so runs sharing a
+// seed see the same spins and results stay in session order. Sessions
+// skipped once stop is closed are left as zero results.
+func (s *rouletteSetup) runSessions(progressions []roulette.ProgressionConfig, numSimulations int, seed int64, bar *pb.ProgressBar) ([]roulette.Result, error) {
+	results := make([]roulette.Result, numSimulations)
+	sessions := make(chan int)
+	var done sync.WaitGroup
+	var mu sync.Mutex
+	var runErr error
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			for i := range sessions {
+				if !stopped(s.stop) {
+					source := roulette.Seeded(s.source, casino.SessionSeed(seed, i))
+					result, err := roulette.RunSimulation(s.variant, source, s.initialBalance, s.bets, progressions, s.limits, s.atLimit, s.profitGoal, s.stopLoss, s.maxSpins)
+					if err != nil {
+						mu.Lock()
+						runErr = err
+						mu.Unlock()
+					}
+					results[i] = result
+					if s.record != nil {
+						s.record(results[i].Balance, results[i].Balance >= s.initialBalance+s.profitGoal, results[i].SpinCount)
+					}
+				}
+				if bar != nil {
+					bar.Increment()
+				}
+			}
+		}()
+	}
+	for i := 0; i < numSimulations && !stopped(s.stop); i++ {
+		sessions <- i
+	}
+	close(sessions)
+	done.Wait()
+	return results, runErr
+}
+
+// rouletteSim runs many sessions of a roulette strategy and reports the
+// win rate, or replays a spin log
+func rouletteSim(args []string) int {
+	fs := newFlagSet("roulette sim", "Simulate a betting strategy over many sessions")
+
+	// Define command-line arguments
+	f := defineRouletteFlags(fs)
+	spinFile := fs.String("spinfile", "", "Replay a spin log, one number per line, instead of spinning the wheel")
+	walkForward := fs.Bool("walkforward", false, "Split the spin log into back-to-back sessions")
+	sessionSpins := fs.Int("sessionspins", 0, "Maximum spins in each spin log session (0 for no limit)")
+	resultsFile := fs.String("results", "", "Write one CSV row per session to this file")
+	showDashboard := fs.Bool("dashboard", false, "Show live statistics in the terminal instead of the progress bar")
+
+	if code, ok := f.parse(fs, args); !ok {
+		return code
+	}
+	s, err := f.setup()
+	if err != nil {
+		return usageError(err)
+	}
+	progressions := s.progressions
+	numSimulations := f.numSimulations
+	if progressions[0].System == roulette.Kelly {
+		for i, bet := range s.bets {
+			fmt.Printf("Kelly sizing for %s: edge %+.4f, variance %.4f, staking %.2f%% of the balance\n", bet, progressions[i].Sizing.Edge, progressions[i].Sizing.Variance, progressions[i].Sizing.Share()*100)
+		}
+	}
+
+	var spins []int
+	if *spinFile != "" {
+		if spins, err = roulette.LoadSpinFile(*spinFile, *f.european); err != nil {
+			return fail(err)
+		}
+	}
+	seed := *f.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	// Run the sessions and report the results
+	code := exitOK
+	simulate := func(progressions []roulette.ProgressionConfig, resultsFile string) (float64, error) {
+		// Replay the spin log when one is given, otherwise run simulations concurrently
+		var results []roulette.Result
+		var err error
+		if *spinFile != "" {
+			// The session cap is the spin cap, so a session stopped by it
+			// counts as capped rather than as cut short by the end of the log
+			maxSpins := s.maxSpins
+			if *sessionSpins > 0 && (maxSpins == 0 || *sessionSpins < maxSpins) {
+				maxSpins = *sessionSpins
+			}
+			play := func(source roulette.SpinSource) {
+				result, playErr := roulette.RunSimulation(s.variant, source, s.initialBalance, s.bets, progressions, s.limits, s.atLimit, s.profitGoal, s.stopLoss, maxSpins)
+				if playErr != nil {
+					err = playErr
+				}
+				results = append(results, result)
+			}
+			if *walkForward {
+				*numSimulations = roulette.WalkForward(spins, play)
+			} else {
+				play(roulette.NewSpinLog(spins, 0))
+				*numSimulations = 1
+			}
+		} else if *showDashboard {
+			// Run simulations concurrently, redrawing the statistics as they finish
+			d := newDashboard(os.Stdout, fmt.Sprintf("%s roulette: %s on %s, seed %d", s.variant.Name(), progressions[0].System, *f.betList, seed), "spins", *numSimulations)
+			s.record = d.record
+			d.start()
+			results, err = s.runSessions(progressions, *numSimulations, seed, nil)
+			d.finish()
+			s.record = nil
+			fmt.Println()
+		} else {
+			// Run simulations concurrently
+			bar := pb.StartNew(*numSimulations)
+			results, err = s.runSessions(progressions, *numSimulations, seed, bar)
+			bar.Finish()
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		// Collect and report results
+		winCount := 0
+		loseCount := 0
+		totalSpins := 0
+		unfinishedCount := 0
+		cappedCount := 0
+		var finalBalance casino.Money
+		spinCounts := make([]int, 0, *numSimulations)
+		winResults := make([]int, 0, *numSimulations)
+		drawdowns := make([]float64, 0, *numSimulations)
+		growth, growthSpins, ruined := 0.0, 0, 0
+		sessions := make([]casino.SessionResult, 0, *numSimulations)
+
+		for _, result := range results {
+			outcome := "lost"
+			if result.Balance >= s.initialBalance+s.profitGoal {
+				winCount++
+				winResults = append(winResults, 1)
+				outcome = "won"
+			} else if result.Exhausted {
+				unfinishedCount++
+				winResults = append(winResults, 0)
+				outcome = "unfinished"
+			} else if result.Capped {
+				cappedCount++
+				winResults = append(winResults, 0)
+				outcome = "capped"
+			} else {
+				loseCount++
+				winResults = append(winResults, 0)
+			}
+			sessions = append(sessions, casino.SessionResult{Outcome: outcome, Balance: result.Balance, Rounds: result.SpinCount, MaxDrawdown: result.MaxDrawdown})
+			finalBalance = result.Balance
+			totalSpins += result.SpinCount
+			spinCounts = append(spinCounts, result.SpinCount)
+			drawdowns = append(drawdowns, result.MaxDrawdown)
+			if g := casino.LogGrowth(s.initialBalance, result.Balance, 1); math.IsInf(g, -1) {
+				ruined++
+			} else {
+				growth += g
+				growthSpins += result.SpinCount
+			}
+		}
+
+		winRate := float64(winCount) / float64(*numSimulations) * 100
+		loseRate := float64(loseCount) / float64(*numSimulations) * 100
+		averageSpins := float64(totalSpins) / float64(*numSimulations)
+		stdDevWinRate := calculateStandardDeviation(winResults, float64(winCount)/float64(*numSimulations)) * 100
+
+		if *spinFile != "" {
+			fmt.Printf("After %d sessions from %s:\n", *numSimulations, *spinFile)
+		} else {
+			fmt.Printf("After %d simulations:\n", *numSimulations)
+		}
+		fmt.Printf("Win rate: %.2f%% (± %.2f%%)\n", winRate, stdDevWinRate)
+		fmt.Printf("Lose rate: %.2f%%\n", loseRate)
+		fmt.Printf("Average number of spins: %.2f\n", averageSpins)
+		if *spinFile != "" {
+			fmt.Printf("Sessions cut short by the end of the log: %d\n", unfinishedCount)
+		}
+		if s.maxSpins > 0 || (*spinFile != "" && *sessionSpins > 0) {
+			fmt.Printf("Sessions stopped at the spin cap: %d\n", cappedCount)
+		}
+		if *spinFile != "" && !*walkForward {
+			fmt.Printf("Final balance: %s\n", finalBalance)
+		}
+
+		// Kelly sizing is judged by how fast the balance grows and how far it falls on the way
+		if progressions[0].System == roulette.Kelly {
+			if growthSpins > 0 {
+				growth /= float64(growthSpins)
+			}
+			if len(progressions) == 1 {
+				fmt.Printf("Growth rate per spin: %.4f%% (expected %.4f%%)\n", growth*100, progressions[0].Sizing.Growth()*100)
+			} else {
+				fmt.Printf("Growth rate per spin: %.4f%%\n", growth*100)
+			}
+			fmt.Printf("Sessions ruined: %d\n", ruined)
+			fmt.Printf("Max drawdown: median %.1f%%, 90th percentile %.1f%%, 99th percentile %.1f%%, worst %.1f%%\n",
+				casino.Percentile(drawdowns, 50)*100, casino.Percentile(drawdowns, 90)*100, casino.Percentile(drawdowns, 99)*100, casino.Percentile(drawdowns, 100)*100)
+		}
+
+		if resultsFile != "" {
+			if err := casino.WriteResults(resultsFile, sessions); err != nil {
+				code = fail(err)
+			}
+		}
+
+		return winRate, nil
+	}
+
+	// With chips, run again with exact stakes to show what rounding changes
+	winRate, err := simulate(progressions, *resultsFile)
+	if err != nil {
+		return fail(err)
+	}
+	if progressions[0].Chips.Enabled() {
+		exact := make([]roulette.ProgressionConfig, len(progressions))
+		for i, cfg := range progressions {
+			exact[i] = cfg
+			exact[i].Chips = casino.Chips{}
+		}
+		fmt.Println("\nWith exact stakes instead of chips:")
+		exactRate, err := simulate(exact, "")
+		if err != nil {
+			return fail(err)
+		}
+		fmt.Printf("\nRounding stakes to chips changes the win rate by %+.2f points\n", winRate-exactRate)
+	}
+	return code
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	casino "github.com/BryceWayne/casino"
+	roulette "github.com/BryceWayne/casino/Roulette"
+)
+
+// pocketLabel writes a pocket with the first letter of its color, such as "17b"
+func pocketLabel(pocket int) string {
+	return roulette.PocketName(pocket) + roulette.Color(pocket)[:1]
+}
+
+// renderLayout draws the betting layout: the zeros, the numbers in three
+// rows of twelve with the columns on the right, then the outside bets
+func renderLayout(w io.Writer, american bool) {
+	zeros := []string{"", "0", ""}
+	if american {
+		zeros = []string{"0", "", "00"}
+	}
+	border := "     +" + strings.Repeat("----", 12) + "-+"
+	fmt.Fprintln(w, border)
+	for row := 0; row < 3; row++ {
+		fmt.Fprintf(w, " %3s |", zeros[row])
+		for column := 0; column < 12; column++ {
+			fmt.Fprintf(w, " %3s", pocketLabel(column*3+3-row))
+		}
+		fmt.Fprintf(w, " | column %d\n", 3-row)
+	}
+	fmt.Fprintln(w, border)
+	fmt.Fprintf(w, "      %-16s%-16s%-16s\n", "dozen 1 (1-12)", "dozen 2 (13-24)", "dozen 3 (25-36)")
+	fmt.Fprintln(w, "      low (1-18)  even  red  black  odd  high (19-36)")
+	fmt.Fprintln(w, "Inside bets: straight 17, split 17-20, street 16-17-18, corner 16-17-19-20, sixline 1-6")
+	if american {
+		fmt.Fprintln(w, "             basket 0-00-1-2-3")
+	}
+}
+
+// parseTableLayout parses bets such as "red:25, 17:5, split 17-20:10",
+// where a bare number is a straight up bet and every bet needs an amount
+func parseTableLayout(text string, variant roulette.Variant) ([]roulette.Bet, error) {
+	fields := strings.Split(text, ",")
+	for i, field := range fields {
+		bet, _, _ := strings.Cut(field, ":")
+		if _, err := roulette.ParsePocket(bet, !variant.American()); err == nil && !strings.Contains(bet, "-") {
+			fields[i] = "straight " + strings.TrimSpace(field)
+		}
+	}
+	bets, err := roulette.ParseBets(strings.Join(fields, ","), variant)
+	if err != nil {
+		return nil, err
+	}
+	for _, bet := range bets {
+		if bet.Amount == 0 {
+			return nil, fmt.Errorf("bet %s needs an amount, such as \"%s:25\"", bet, bet)
+		}
+	}
+	return bets, nil
+}
+
+// rouletteTable is an interactive roulette table: the wheel, the layout
+// and the player's bankroll
+type rouletteTable struct {
+	out     io.Writer
+	variant roulette.Classic
+	source  roulette.SpinSource
+	limits  roulette.TableLimits
+	balance casino.Money
+	start   casino.Money
+
+	spins       []int
+	counts      map[int]int
+	wagered     casino.Money
+	biggestWin  casino.Money
+	biggestLoss casino.Money
+	lastBets    []roulette.Bet
+}
+
+// spin plays one round with the bets and settles every one
+func (t *rouletteTable) spin(bets []roulette.Bet) {
+	round := t.variant.Spin(t.source)
+	pocket := round.Balls[0]
+	fmt.Fprintf(t.out, "Spin: %s %s\n", roulette.PocketName(pocket), roulette.Color(pocket))
+
+	var net casino.Money
+	for _, bet := range bets {
+		settlement := t.variant.Settle(bet, round)
+		fmt.Fprintf(t.out, "  %-22s %8s: %s %s\n", bet, bet.Amount, settlement.Outcome, settlement.Net)
+		net += settlement.Net
+		t.wagered += bet.Amount
+	}
+	t.balance += net
+	if net > t.biggestWin {
+		t.biggestWin = net
+	}
+	if -net > t.biggestLoss {
+		t.biggestLoss = -net
+	}
+	t.spins = append(t.spins, pocket)
+	t.counts[pocket]++
+	fmt.Fprintf(t.out, "Net %s, balance %s\n", net, t.balance)
+	t.recent()
+}
+
+// recent shows the last 20 numbers, newest first
+func (t *rouletteTable) recent() {
+	var labels []string
+	for i := len(t.spins) - 1; i >= 0 && len(labels) < 20; i-- {
+		labels = append(labels, pocketLabel(t.spins[i]))
+	}
+	fmt.Fprintf(t.out, "Last %d: %s\n", len(labels), strings.Join(labels, " "))
+}
+
+// hotCold returns the most and the least frequent pockets of the session
+func (t *rouletteTable) hotCold(n int) ([]int, []int) {
+	pockets := roulette.WheelOrder(t.variant.European)
+	sorted := append([]int(nil), pockets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if t.counts[sorted[i]] != t.counts[sorted[j]] {
+			return t.counts[sorted[i]] > t.counts[sorted[j]]
+		}
+		return sorted[i] < sorted[j]
+	})
+	hot := sorted[:n]
+	cold := append([]int(nil), sorted[len(sorted)-n:]...)
+	sort.SliceStable(cold, func(i, j int) bool { return t.counts[cold[i]] < t.counts[cold[j]] })
+	return hot, cold
+}
+
+// stats shows the hot and cold numbers and how the colors have come up
+func (t *rouletteTable) stats() {
+	if len(t.spins) == 0 {
+		fmt.Fprintln(t.out, "No spins yet")
+		return
+	}
+	describe := func(pockets []int) string {
+		labels := make([]string, len(pockets))
+		for i, pocket := range pockets {
+			labels[i] = fmt.Sprintf("%s (%d)", pocketLabel(pocket), t.counts[pocket])
+		}
+		return strings.Join(labels, ", ")
+	}
+	hot, cold := t.hotCold(5)
+	colors := map[string]int{}
+	for _, pocket := range t.spins {
+		colors[roulette.Color(pocket)]++
+	}
+	n := float64(len(t.spins))
+	fmt.Fprintf(t.out, "Spins: %d, red %.1f%%, black %.1f%%, green %.1f%%\n", len(t.spins), float64(colors["red"])/n*100, float64(colors["black"])/n*100, float64(colors["green"])/n*100)
+	fmt.Fprintf(t.out, "Hot:  %s\n", describe(hot))
+	fmt.Fprintf(t.out, "Cold: %s\n", describe(cold))
+	t.recent()
+}
+
+// summary reports the session when the player leaves the table
+func (t *rouletteTable) summary() {
+	fmt.Fprintln(t.out, "\nSession summary")
+	fmt.Fprintf(t.out, "Spins played:  %d\n", len(t.spins))
+	fmt.Fprintf(t.out, "Total wagered: %s\n", t.wagered)
+	fmt.Fprintf(t.out, "Biggest win:   %s\n", t.biggestWin)
+	fmt.Fprintf(t.out, "Biggest loss:  %s\n", t.biggestLoss)
+	fmt.Fprintf(t.out, "Result:        %s, from %s to %s\n", t.balance-t.start, t.start, t.balance)
+}
+
+// play takes bets at the prompt and spins until the player quits, runs
+// out of money or the input ends
+func (t *rouletteTable) play(in io.Reader) {
+	renderLayout(t.out, t.variant.American())
+	scanner := bufio.NewScanner(in)
+	for t.balance > 0 {
+		fmt.Fprintf(t.out, "\nBalance %s. Bets such as \"red:25, 17:5\", Enter to repeat, l for the layout, s for statistics, q to quit: ", t.balance)
+		if !scanner.Scan() {
+			fmt.Fprintln(t.out)
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		switch strings.ToLower(line) {
+		case "q", "quit", "exit":
+			t.summary()
+			return
+		case "l", "layout":
+			renderLayout(t.out, t.variant.American())
+			continue
+		case "s", "stats":
+			t.stats()
+			continue
+		case "h", "help", "?":
+			fmt.Fprintln(t.out, "Write each bet and its amount after a colon, separated by commas: red:25, dozen 3:10, split 17-20:5, 0:1")
+			continue
+		}
+
+		bets := t.lastBets
+		if line != "" {
+			var err error
+			if bets, err = parseTableLayout(line, t.variant); err != nil {
+				fmt.Fprintln(t.out, "Error:", err)
+				continue
+			}
+		} else if len(bets) == 0 {
+			fmt.Fprintln(t.out, "No bets to repeat yet")
+			continue
+		}
+		if err := t.limits.Check(bets); err != nil {
+			fmt.Fprintln(t.out, "Error:", err)
+			continue
+		}
+		var total casino.Money
+		for _, bet := range bets {
+			total += bet.Amount
+		}
+		if total > t.balance {
+			fmt.Fprintf(t.out, "Error: bets of %s are more than the %s you have\n", total, t.balance)
+			continue
+		}
+		t.lastBets = bets
+		t.spin(bets)
+	}
+	if t.balance <= 0 {
+		fmt.Fprintln(t.out, "\nOut of money")
+	}
+	t.summary()
+}
+
+// roulettePlay sits the player at an interactive roulette table
+func roulettePlay(args []string) int {
+	fs := newFlagSet("roulette play", "Play roulette at an interactive table")
+
+	// Define command-line arguments
+	config := fs.String("config", "", "Scenario file (YAML or JSON) with the settings; flags override it")
+	european := fs.Bool("european", false, "Use European wheel (single 0)")
+	laPartage := fs.Bool("lapartage", false, "Return half of a losing even-money bet on zero (European wheel only)")
+	initialBalance := casino.MoneyFlag(fs, "balance", casino.Dollars(1_000), "Player's balance, unless the wallet holds one")
+	insideMin := casino.MoneyFlag(fs, "insidemin", 0, "Table minimum for each inside bet")
+	insideMax := casino.MoneyFlag(fs, "insidemax", 0, "Table maximum for each inside bet (0 for no limit)")
+	outsideMin := casino.MoneyFlag(fs, "outsidemin", 0, "Table minimum for each outside bet")
+	outsideMax := casino.MoneyFlag(fs, "outsidemax", 0, "Table maximum for each outside bet (0 for no limit)")
+	tableMin := casino.MoneyFlag(fs, "tablemin", 0, "Table minimum for the total staked on a spin")
+	tableMax := casino.MoneyFlag(fs, "tablemax", 0, "Table maximum for the total staked on a spin (0 for no limit)")
+	walletFile := fs.String("wallet", "", "Keep the balance in this file from one session to the next")
+	seed := fs.Int64("seed", 0, "Seed of the spins (0 for a random seed)")
+
+	if code, ok := parseFlags(fs, args, config, "roulette"); !ok {
+		return code
+	}
+	if *laPartage && !*european {
+		return usageError(fmt.Errorf("la partage needs a European wheel"))
+	}
+	wheel, err := roulette.NewWheel(*european, nil)
+	if err != nil {
+		return usageError(err)
+	}
+
+	balance := *initialBalance
+	if *walletFile != "" {
+		saved, ok, err := loadWallet(*walletFile)
+		if err != nil {
+			return fail(err)
+		}
+		if ok {
+			balance = saved
+			fmt.Printf("Balance %s from %s\n", balance, *walletFile)
+		}
+	}
+	if balance <= 0 {
+		return fail(fmt.Errorf("no money to play with; give a -balance or remove the wallet %s", *walletFile))
+	}
+
+	table := &rouletteTable{
+		out:     os.Stdout,
+		variant: roulette.Classic{European: *european, LaPartage: *laPartage},
+		source:  roulette.Seeded(wheel, compareSeed(*seed)),
+		limits: roulette.TableLimits{
+			Inside:  roulette.Limit{Min: *insideMin, Max: *insideMax},
+			Outside: roulette.Limit{Min: *outsideMin, Max: *outsideMax},
+			Total:   roulette.Limit{Min: *tableMin, Max: *tableMax},
+		},
+		balance: balance,
+		start:   balance,
+		counts:  map[int]int{},
+	}
+	fmt.Printf("%s roulette\n", table.variant.Name())
+	table.play(os.Stdin)
+
+	if *walletFile != "" {
+		if err := saveWallet(*walletFile, table.balance); err != nil {
+			return fail(err)
+		}
+		fmt.Printf("Balance saved to %s\n", *walletFile)
+	}
+	return exitOK
+}
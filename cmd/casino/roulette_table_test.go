@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+	roulette "github.com/BryceWayne/casino/Roulette"
+)
+
+func TestParseTableLayout(t *testing.T) {
+	bets, err := parseTableLayout("red:25, 17:5, split 17-20:10", roulette.Classic{})
+	if err != nil || len(bets) != 3 {
+		t.Fatalf("parseTableLayout = %v, %v", bets, err)
+	}
+	if bets[1].Kind != roulette.Straight || bets[1].Numbers[0] != 17 || bets[1].Amount != casino.Dollars(5) {
+		t.Errorf("bare number = %+v, want a $5 straight on 17", bets[1])
+	}
+	for _, text := range []string{"red", "17", "37:5", "dragon:5"} {
+		if _, err := parseTableLayout(text, roulette.Classic{}); err == nil {
+			t.Errorf("parseTableLayout(%q) accepted", text)
+		}
+	}
+	if _, err := parseTableLayout("00:5", roulette.Classic{European: true}); err == nil {
+		t.Error("parseTableLayout accepted 00 on a European wheel")
+	}
+}
+
+func TestRouletteTable(t *testing.T) {
+	var out bytes.Buffer
+	wheel, err := roulette.NewWheel(true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := &rouletteTable{
+		out:     &out,
+		variant: roulette.Classic{European: true},
+		source:  roulette.Seeded(wheel, 1),
+		limits:  roulette.TableLimits{Inside: roulette.Limit{Max: casino.Dollars(50)}},
+		balance: casino.Dollars(1000),
+		start:   casino.Dollars(1000),
+		counts:  map[int]int{},
+	}
+	table.play(strings.NewReader("red:25, 17:5\n\n\n17:100\ns\nq\n"))
+
+	if len(table.spins) != 3 {
+		t.Errorf("played %d spins, want 3", len(table.spins))
+	}
+	if table.wagered != casino.Dollars(90) {
+		t.Errorf("wagered %s, want $90", table.wagered)
+	}
+	for _, want := range []string{"outside the table limit", "Hot:", "Last 3:", "Session summary"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, out.String())
+		}
+	}
+	hot, cold := table.hotCold(5)
+	if table.counts[hot[0]] < table.counts[cold[0]] || table.counts[cold[0]] != 0 {
+		t.Errorf("hot %v and cold %v are out of order", hot, cold)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/BryceWayne/casino/server"
+)
+
+// serve runs the HTTP/JSON API for baccarat and roulette tables
+func serve(args []string) int {
+	fs := newFlagSet("serve", "Serve baccarat and roulette tables over an HTTP/JSON API")
+
+	// Define command-line arguments
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	seed := fs.Int64("seed", 0, "Seed of the shoes and wheels; a server started with the same seed deals the same rounds (0 for a random seed)")
+
+	if code, ok := parseFlags(fs, args, nil, ""); !ok {
+		return code
+	}
+	fmt.Printf("Serving tables on http://%s\n", *addr)
+	if err := http.ListenAndServe(*addr, server.New(*seed)); err != nil {
+		return fail(err)
+	}
+	return exitOK
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	roulette "github.com/BryceWayne/casino/Roulette"
+	"github.com/cheggaaa/pb/v3"
+)
+
+// sweep is a flag and the values it takes across the grid
+type sweep struct {
+	name   string
+	values []string
+}
+
+// sweepList collects the repeated -sweep flag
+type sweepList []sweep
+
+func (l *sweepList) String() string {
+	fields := make([]string, len(*l))
+	for i, s := range *l {
+		fields[i] = s.name + "=" + strings.Join(s.values, ",")
+	}
+	return strings.Join(fields, " ")
+}
+
+func (l *sweepList) Set(value string) error {
+	s, err := parseSweep(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, s)
+	return nil
+}
+
+// parseSweep parses "name=a,b,c" or the range "name=start:stop:step"
+func parseSweep(text string) (sweep, error) {
+	name, values, ok := strings.Cut(text, "=")
+	name = strings.TrimPrefix(strings.TrimSpace(name), "-")
+	if !ok || name == "" || strings.TrimSpace(values) == "" {
+		return sweep{}, fmt.Errorf("invalid sweep %q, want name=a,b,c or name=start:stop:step", text)
+	}
+	s := sweep{name: name}
+	if strings.Count(values, ":") != 2 || strings.Contains(values, ",") {
+		for _, value := range strings.Split(values, ",") {
+			s.values = append(s.values, strings.TrimSpace(value))
+		}
+		return s, nil
+	}
+
+	bounds := strings.Split(values, ":")
+	numbers := make([]float64, 3)
+	for i, bound := range bounds {
+		n, err := strconv.ParseFloat(strings.TrimSpace(bound), 64)
+		if err != nil {
+			return sweep{}, fmt.Errorf("invalid range %q for %s", values, name)
+		}
+		numbers[i] = n
+	}
+	start, stop, step := numbers[0], numbers[1], numbers[2]
+	if step <= 0 || stop < start {
+		return sweep{}, fmt.Errorf("invalid range %q for %s: the step must be positive and the stop at least the start", values, name)
+	}
+	count := int(math.Floor((stop-start)/step+1e-9)) + 1
+	for i := 0; i < count; i++ {
+		value := start + float64(i)*step
+		s.values = append(s.values, strconv.FormatFloat(math.Round(value*1e6)/1e6, 'f', -1, 64))
+	}
+	return s, nil
+}
+
+// grid returns every combination of the swept values, the first sweep varying slowest
+func grid(sweeps []sweep) [][]string {
+	combinations := [][]string{{}}
+	for _, s := range sweeps {
+		var next [][]string
+		for _, combination := range combinations {
+			for _, value := range s.values {
+				next = append(next, append(append([]string{}, combination...), value))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// sweepResult is how one combination of the grid played
+type sweepResult struct {
+	values       []string
+	winRate      float64
+	ev           float64
+	lost         float64 // Sessions that ended short of both the goal and the spin cap
+	averageSpins float64
+}
+
+// summarize works out the metrics of a run of sessions
+func summarize(s *rouletteSetup, sessions []roulette.Result) sweepResult {
+	var result sweepResult
+	var net casino.Money
+	won, lost, spins := 0, 0, 0
+	for _, session := range sessions {
+		net += session.Balance - s.initialBalance
+		spins += session.SpinCount
+		if session.Balance >= s.initialBalance+s.profitGoal {
+			won++
+		} else if !session.Capped {
+			lost++
+		}
+	}
+	n := float64(len(sessions))
+	result.winRate = float64(won) / n * 100
+	result.lost = float64(lost) / n * 100
+	result.ev = net.Float() / n
+	result.averageSpins = float64(spins) / n
+	return result
+}
+
+// sweepMetrics are the metrics a heatmap can show
+var sweepMetrics = map[string]func(r sweepResult) float64{
+	"winrate": func(r sweepResult) float64 { return r.winRate },
+	"ev":      func(r sweepResult) float64 { return r.ev },
+	"lost":    func(r sweepResult) float64 { return r.lost },
+	"spins":   func(r sweepResult) float64 { return r.averageSpins },
+}
+
+// sweepFlags defines the flags of the sweep command
+func sweepFlags() (*flag.FlagSet, *rouletteFlags, *sweepList, *string, *string) {
+	fs := newFlagSet("roulette sweep", "Simulate a strategy over a grid of settings with common random numbers")
+	f := defineRouletteFlags(fs)
+	sweeps := &sweepList{}
+	fs.Var(sweeps, "sweep", "Setting to sweep as name=a,b,c or name=start:stop:step, such as bet=50:200:50 (repeat for a grid)")
+	csvFile := fs.String("csv", "", "Write one CSV row per combination to this file")
+	heatmap := fs.String("heatmap", "winrate", "Metric shown in the heatmap (winrate, ev, lost or spins)")
+	return fs, f, sweeps, csvFile, heatmap
+}
+
+// rouletteSweep runs a strategy for every combination of the swept
+// settings. Session i of every combination spins from the same seed, so
+// differences between combinations come from the settings, not the luck.
+func rouletteSweep(args []string) int {
+	fs, f, sweeps, csvFile, heatmap := sweepFlags()
+	if code, ok := parseFlags(fs, args, f.config, "roulette"); !ok {
+		return code
+	}
+	if len(*sweeps) == 0 {
+		return usageError(fmt.Errorf("nothing to sweep, give at least one -sweep"))
+	}
+	metric, ok := sweepMetrics[*heatmap]
+	if !ok {
+		return usageError(fmt.Errorf("unknown heatmap metric %q", *heatmap))
+	}
+	for _, s := range *sweeps {
+		switch s.name {
+		case "config", "sweep", "csv", "heatmap", "seed", "simulations":
+			return usageError(fmt.Errorf("cannot sweep -%s", s.name))
+		}
+		if fs.Lookup(s.name) == nil {
+			return usageError(fmt.Errorf("cannot sweep unknown flag -%s", s.name))
+		}
+	}
+
+	seed := *f.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	combinations := grid(*sweeps)
+	numSimulations := *f.numSimulations
+	fmt.Printf("Sweeping %d combinations of %d simulations with seed %d\n", len(combinations), numSimulations, seed)
+
+	// Build every combination before running any, so a bad value fails fast
+	setups := make([]*rouletteSetup, len(combinations))
+	for i, values := range combinations {
+		fs, f, _, _, _ := sweepFlags()
+		if code, ok := parseFlags(fs, args, f.config, "roulette"); !ok {
+			return code
+		}
+		for j, s := range *sweeps {
+			if err := fs.Set(s.name, values[j]); err != nil {
+				return usageError(fmt.Errorf("invalid value %q for -%s: %v", values[j], s.name, err))
+			}
+		}
+		if err := applyStrategy(fs, *f.strategy); err != nil {
+			return usageError(err)
+		}
+		setup, err := f.setup()
+		if err != nil {
+			return usageError(fmt.Errorf("%s: %v", describe(*sweeps, values), err))
+		}
+		setups[i] = setup
+	}
+
+	bar := pb.StartNew(len(combinations) * numSimulations)
+	results := make([]sweepResult, len(combinations))
+	for i, s := range setups {
+		sessions, err := s.runSessions(s.progressions, numSimulations, seed, bar)
+		if err != nil {
+			return fail(err)
+		}
+		result := summarize(s, sessions)
+		result.values = combinations[i]
+		results[i] = result
+	}
+	bar.Finish()
+
+	printSweepTable(*sweeps, results)
+	printHeatmap(*sweeps, results, *heatmap, metric)
+	if *csvFile != "" {
+		if err := writeSweep(*csvFile, *sweeps, results); err != nil {
+			return fail(err)
+		}
+	}
+	return exitOK
+}
+
+// describe names a combination such as "bet=50 profit=1000"
+func describe(sweeps []sweep, values []string) string {
+	fields := make([]string, len(sweeps))
+	for i, s := range sweeps {
+		fields[i] = s.name + "=" + values[i]
+	}
+	return strings.Join(fields, " ")
+}
+
+// printSweepTable prints one row per combination
+func printSweepTable(sweeps []sweep, results []sweepResult) {
+	fmt.Println()
+	for _, s := range sweeps {
+		fmt.Printf("%-12s", s.name)
+	}
+	fmt.Printf("%10s %14s %10s %12s\n", "win rate", "EV/session", "lost", "avg spins")
+	for _, r := range results {
+		for _, value := range r.values {
+			fmt.Printf("%-12s", value)
+		}
+		fmt.Printf("%9.2f%% %14.2f %9.2f%% %12.2f\n", r.winRate, r.ev, r.lost, r.averageSpins)
+	}
+}
+
+// shades run from the lowest value of a heatmap to the highest
+const shades = " .:-=+*#%@"
+
+// printHeatmap prints the metric with the first swept setting down the
+// side and the second across the top, one map for each combination of
+// any further settings
+func printHeatmap(sweeps []sweep, results []sweepResult, name string, metric func(r sweepResult) float64) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, r := range results {
+		low = math.Min(low, metric(r))
+		high = math.Max(high, metric(r))
+	}
+	shade := func(value float64) string {
+		i := 0
+		if high > low {
+			i = int((value - low) / (high - low) * float64(len(shades)-1))
+		}
+		return strings.Repeat(string(shades[i]), 2)
+	}
+
+	rows := sweeps[0]
+	columns := sweep{values: []string{""}}
+	if len(sweeps) > 1 {
+		columns = sweeps[1]
+	}
+	rest := 1
+	if len(sweeps) > 2 {
+		rest = len(results) / (len(rows.values) * len(columns.values))
+	}
+
+	// The grid varies the last sweep fastest, so the further settings are the low part of the index
+	for k := 0; k < rest; k++ {
+		fmt.Printf("\n%s heatmap", name)
+		if len(sweeps) > 2 {
+			fmt.Printf(" at %s", describe(sweeps[2:], results[k].values[2:]))
+		}
+		fmt.Printf(" (%q low to %q high)\n", shades[0], shades[len(shades)-1])
+
+		corner := rows.name
+		if len(sweeps) > 1 {
+			corner += " \\ " + columns.name
+		}
+		fmt.Printf("%-12s", corner)
+		for _, value := range columns.values {
+			fmt.Printf(" %12s", value)
+		}
+		fmt.Println()
+		for i, row := range rows.values {
+			fmt.Printf("%-12s", row)
+			for j := range columns.values {
+				r := results[(i*len(columns.values)+j)*rest+k]
+				fmt.Printf(" %s %9.2f", shade(metric(r)), metric(r))
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// writeSweep writes one CSV row per combination
+func writeSweep(path string, sweeps []sweep, results []sweepResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := make([]string, 0, len(sweeps)+4)
+	for _, s := range sweeps {
+		header = append(header, s.name)
+	}
+	writer.Write(append(header, "win_rate", "ev_per_session", "loss_rate", "avg_spins"))
+	for _, r := range results {
+		row := append([]string{}, r.values...)
+		writer.Write(append(row,
+			strconv.FormatFloat(r.winRate/100, 'f', 4, 64),
+			strconv.FormatFloat(r.ev, 'f', 2, 64),
+			strconv.FormatFloat(r.lost/100, 'f', 4, 64),
+			strconv.FormatFloat(r.averageSpins, 'f', 2, 64),
+		))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
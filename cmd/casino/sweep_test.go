@@ -0,0 +1,121 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	roulette "github.com/BryceWayne/casino/Roulette"
+)
+
+func TestParseSweep(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"bet=50:200:50", []string{"50", "100", "150", "200"}},
+		{"-kelly=0.1:0.3:0.1", []string{"0.1", "0.2", "0.3"}},
+		{"progression=fibonacci, martingale", []string{"fibonacci", "martingale"}},
+		{"european=true", []string{"true"}},
+	}
+	for _, c := range cases {
+		s, err := parseSweep(c.text)
+		if err != nil {
+			t.Errorf("parseSweep(%q): %v", c.text, err)
+			continue
+		}
+		if !reflect.DeepEqual(s.values, c.want) {
+			t.Errorf("parseSweep(%q) = %v, want %v", c.text, s.values, c.want)
+		}
+	}
+	for _, text := range []string{"bet", "=1,2", "bet=", "bet=200:50:50", "bet=1:x:1"} {
+		if _, err := parseSweep(text); err == nil {
+			t.Errorf("parseSweep(%q) accepted", text)
+		}
+	}
+}
+
+// spinRecorder is a wheel that keeps the spins of every session it is
+// seeded for. It names each session by a first draw from the session's
+// generator, which shifts the spins of every run alike.
+type spinRecorder struct {
+	roulette.SpinSource
+	mu       *sync.Mutex
+	sessions map[int64]*[]int
+	spins    *[]int
+}
+
+func newSpinRecorder(source roulette.SpinSource) *spinRecorder {
+	return &spinRecorder{SpinSource: source, mu: &sync.Mutex{}, sessions: map[int64]*[]int{}}
+}
+
+func (r *spinRecorder) WithRand(rng *rand.Rand) roulette.SpinSource {
+	key := rng.Int63()
+	seeded := &spinRecorder{SpinSource: r.SpinSource.(roulette.Seeder).WithRand(rng), spins: &[]int{}}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[key] = seeded.spins
+	return seeded
+}
+
+func (r *spinRecorder) Spin() int {
+	pocket := r.SpinSource.Spin()
+	*r.spins = append(*r.spins, pocket)
+	return pocket
+}
+
+func TestSweepCommonRandomNumbers(t *testing.T) {
+	run := func(args ...string) ([]int, map[int64]*[]int) {
+		fs, f, _, _, _ := sweepFlags()
+		if _, ok := parseFlags(fs, args, f.config, "roulette"); !ok {
+			t.Fatalf("parse %v", args)
+		}
+		if err := applyStrategy(fs, *f.strategy); err != nil {
+			t.Fatal(err)
+		}
+		s, err := f.setup()
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorder := newSpinRecorder(s.source)
+		s.source = recorder
+		results, err := s.runSessions(s.progressions, 50, 42, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var spins []int
+		for _, r := range results {
+			spins = append(spins, r.SpinCount)
+		}
+		return spins, recorder.sessions
+	}
+	first, _ := run("-strategy", "martingale")
+	if again, _ := run("-strategy", "martingale"); !reflect.DeepEqual(first, again) {
+		t.Errorf("the same seed played differently: %v and %v", first, again)
+	}
+
+	// Different settings stop at different times, but session i of each
+	// sees the same spins for as long as both keep playing
+	flat, flatSpins := run("-strategy", "martingale", "-progression", "flat", "-bets", "black")
+	martingale, martingaleSpins := run("-strategy", "martingale")
+	if reflect.DeepEqual(flat, martingale) {
+		t.Fatalf("flat and martingale sessions lasted the same: %v", flat)
+	}
+	if len(flatSpins) != 50 || len(martingaleSpins) != 50 {
+		t.Fatalf("%d and %d sessions seeded, want 50", len(flatSpins), len(martingaleSpins))
+	}
+	for key, spins := range flatSpins {
+		other, ok := martingaleSpins[key]
+		if !ok {
+			t.Fatalf("session %d was seeded for flat but not martingale", key)
+		}
+		a, b := *spins, *other
+		if len(a) > len(b) {
+			a, b = b, a
+		}
+		if !reflect.DeepEqual(a, b[:len(a)]) {
+			t.Errorf("the settings spun differently from the same seed: %v and %v", a, b[:len(a)])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package casino
+
+import (
+	"fmt"
+	"math"
+)
+
+// CommissionRule decides how commission on winning bets is paid
+type CommissionRule string
+
+const (
+	CommissionExact CommissionRule = "exact" // Deduct the exact commission, to the cent, from each win
+	CommissionRound CommissionRule = "round" // Pay each win rounded down to the minimum unit, usually the smallest chip
+	CommissionOwed  CommissionRule = "owed"  // Pay wins in full and collect the commission owed at the end of the shoe
+)
+
+// ParseCommissionRule checks a commission rule given on the command line
+func ParseCommissionRule(s string) (CommissionRule, error) {
+	switch rule := CommissionRule(s); rule {
+	case CommissionExact, CommissionRound, CommissionOwed:
+		return rule, nil
+	}
+	return "", fmt.Errorf("unknown commission rule %q", s)
+}
+
+// Commission charges a rate on winning bets, such as 5% on Banker wins
+type Commission struct {
+	Rate float64
+	Rule CommissionRule
+	Unit Money // Smallest chip paid or collected, one cent when zero
+	Owed Money // Commission tracked but not yet collected
+}
+
+// unit returns the minimum unit of the commission
+func (c *Commission) unit() Money {
+	if c.Unit <= 0 {
+		return Cent
+	}
+	return c.Unit
+}
+
+// due returns the commission on a win, rounded up to the cent
+func (c *Commission) due(win Money) Money {
+	// Round away floating point noise before rounding up, so that 5% of
+	// $25 is exactly $1.25
+	return Money(math.Ceil(math.Round(float64(win)*c.Rate*1e6) / 1e6))
+}
+
+// Charge takes the commission on a win and returns the net amount paid now
+func (c *Commission) Charge(win Money) Money {
+	switch c.Rule {
+	case CommissionOwed:
+		c.Owed += c.due(win)
+		return win
+	case CommissionRound:
+		return (win - c.due(win)).Floor(c.unit())
+	}
+	return win - c.due(win)
+}
+
+// Collect returns the commission owed, rounded up to the minimum unit, and
+// clears it, as the dealer does at the end of a shoe or when the player
+// leaves the table
+func (c *Commission) Collect() Money {
+	owed := c.Owed.Ceil(c.unit())
+	c.Owed = 0
+	return owed
+}
@@ -0,0 +1,543 @@
+// Package floor simulates a casino floor from the house's side: tables of
+// baccarat and roulette dealing all day, and players arriving at random to
+// buy in, bet their strategy and cash out. It reports what the operations
+// team watches: the drop, the handle, the theoretical and actual win, the
+// hold and how much a table's day swings.
+package floor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	casino "github.com/BryceWayne/casino"
+	baccarat "github.com/BryceWayne/casino/Baccarat"
+	roulette "github.com/BryceWayne/casino/Roulette"
+	"gopkg.in/yaml.v3"
+)
+
+// Floor is the casino floor to simulate: its tables, the players who come
+// to them and how long it is open each day
+type Floor struct {
+	Tables          []TableConfig `json:"tables" yaml:"tables"`
+	Players         []Profile     `json:"players" yaml:"players"`
+	ArrivalsPerHour float64       `json:"arrivals_per_hour" yaml:"arrivals_per_hour"` // Players walking up to the tables, on average
+	HoursPerDay     int           `json:"hours_per_day" yaml:"hours_per_day"`         // Everyone cashes out at closing
+}
+
+// TableConfig is a group of identical tables
+type TableConfig struct {
+	Name           string       `json:"name" yaml:"name"`
+	Game           string       `json:"game" yaml:"game"` // baccarat or roulette
+	Count          int          `json:"count" yaml:"count"`
+	Decks          int          `json:"decks" yaml:"decks"`                     // Baccarat shoe, 8 by default
+	CommissionRate float64      `json:"commission_rate" yaml:"commission_rate"` // Baccarat commission on Banker wins, 5% by default
+	Variant        string       `json:"variant" yaml:"variant"`                 // Roulette variant, american by default
+	MinBet         casino.Money `json:"min_bet" yaml:"min_bet"`
+	MaxBet         casino.Money `json:"max_bet" yaml:"max_bet"`
+	RoundsPerHour  int          `json:"rounds_per_hour" yaml:"rounds_per_hour"` // Hands or spins dealt in an hour
+	Seats          int          `json:"seats" yaml:"seats"`
+}
+
+// Profile is a kind of player: how often they come, what they bring and
+// how they bet
+type Profile struct {
+	Name     string       `json:"name" yaml:"name"`
+	Share    float64      `json:"share" yaml:"share"` // Weight among the arrivals
+	Game     string       `json:"game" yaml:"game"`
+	Bankroll casino.Money `json:"bankroll" yaml:"bankroll"` // Bought in as chips on sitting down
+	Bet      casino.Money `json:"bet" yaml:"bet"`           // Unit bet
+	On       string       `json:"on" yaml:"on"`             // Player, Banker or Tie, or a roulette bet such as "red" or "dozen 3"
+	Strategy string       `json:"strategy" yaml:"strategy"` // flat, or martingale to double after each loss
+	Hours    float64      `json:"hours" yaml:"hours"`       // How long they mean to play
+	WinGoal  casino.Money `json:"win_goal" yaml:"win_goal"` // Profit at which they leave, 0 to play on
+}
+
+// Default is a mid-sized floor: mass and high-limit baccarat, American and
+// European roulette, and the players each draws
+func Default() *Floor {
+	return &Floor{
+		Tables: []TableConfig{
+			{Name: "Baccarat $25", Game: "baccarat", Count: 4, MinBet: casino.Dollars(25), MaxBet: casino.Dollars(5000), RoundsPerHour: 70, Seats: 7},
+			{Name: "Baccarat high limit", Game: "baccarat", Count: 2, MinBet: casino.Dollars(100), MaxBet: casino.Dollars(25000), RoundsPerHour: 60, Seats: 7},
+			{Name: "Roulette $10", Game: "roulette", Count: 4, Variant: "american", MinBet: casino.Dollars(10), MaxBet: casino.Dollars(1000), RoundsPerHour: 40, Seats: 8},
+			{Name: "Roulette European", Game: "roulette", Count: 1, Variant: "european", MinBet: casino.Dollars(25), MaxBet: casino.Dollars(2500), RoundsPerHour: 35, Seats: 8},
+		},
+		Players: []Profile{
+			{Name: "Casual Banker", Share: 0.3, Game: "baccarat", Bankroll: casino.Dollars(500), Bet: casino.Dollars(25), On: "Banker", Strategy: "flat", Hours: 1.5},
+			{Name: "Casual Player", Share: 0.15, Game: "baccarat", Bankroll: casino.Dollars(500), Bet: casino.Dollars(25), On: "Player", Strategy: "flat", Hours: 1.5},
+			{Name: "Martingale", Share: 0.1, Game: "baccarat", Bankroll: casino.Dollars(3000), Bet: casino.Dollars(25), On: "Banker", Strategy: "martingale", Hours: 2, WinGoal: casino.Dollars(500)},
+			{Name: "High roller", Share: 0.05, Game: "baccarat", Bankroll: casino.Dollars(20000), Bet: casino.Dollars(500), On: "Banker", Strategy: "flat", Hours: 3},
+			{Name: "Red or black", Share: 0.25, Game: "roulette", Bankroll: casino.Dollars(200), Bet: casino.Dollars(10), On: "red", Strategy: "flat", Hours: 1},
+			{Name: "Dozens", Share: 0.1, Game: "roulette", Bankroll: casino.Dollars(500), Bet: casino.Dollars(25), On: "dozen 2", Strategy: "flat", Hours: 1.5},
+			{Name: "Straight up", Share: 0.05, Game: "roulette", Bankroll: casino.Dollars(300), Bet: casino.Dollars(10), On: "straight 17", Strategy: "flat", Hours: 1},
+		},
+		ArrivalsPerHour: 30,
+		HoursPerDay:     24,
+	}
+}
+
+// Load reads a floor from a .json file, or from YAML otherwise. Unknown
+// settings are errors so that a misspelt key is not ignored.
+func Load(path string) (*Floor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f Floor
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&f)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &f, nil
+}
+
+// kind is a table configuration ready to deal
+type kind struct {
+	TableConfig
+	variant    roulette.Variant
+	wheel      roulette.SpinSource
+	commission casino.Commission
+	edges      map[string]float64 // House edge of each baccarat bet
+}
+
+// profile is a player profile ready to play
+type profile struct {
+	Profile
+	bets map[*kind]roulette.Bet // The roulette bet at each kind of table, for roulette players
+}
+
+// prepare checks the floor and fills in its defaults
+func (f *Floor) prepare() ([]*kind, []*profile, error) {
+	if f.ArrivalsPerHour <= 0 {
+		return nil, nil, fmt.Errorf("arrivals_per_hour must be positive")
+	}
+	if f.HoursPerDay <= 0 || f.HoursPerDay > 24 {
+		return nil, nil, fmt.Errorf("hours_per_day %d is not between 1 and 24", f.HoursPerDay)
+	}
+	if len(f.Tables) == 0 || len(f.Players) == 0 {
+		return nil, nil, fmt.Errorf("a floor needs tables and players")
+	}
+
+	var kinds []*kind
+	odds := map[int][3]float64{} // By number of decks, as working them out takes a while
+	for _, t := range f.Tables {
+		k := &kind{TableConfig: t}
+		if k.Name == "" {
+			k.Name = t.Game
+		}
+		if t.Count <= 0 || t.RoundsPerHour <= 0 || t.Seats <= 0 {
+			return nil, nil, fmt.Errorf("%s: count, rounds_per_hour and seats must be positive", k.Name)
+		}
+		if t.MinBet <= 0 || t.MaxBet < t.MinBet {
+			return nil, nil, fmt.Errorf("%s: limits %s to %s are invalid", k.Name, t.MinBet, t.MaxBet)
+		}
+		switch t.Game {
+		case "baccarat":
+			if k.Decks == 0 {
+				k.Decks = 8
+			}
+			if k.CommissionRate == 0 {
+				k.CommissionRate = 0.05
+			}
+			if k.Decks < 1 || k.CommissionRate < 0 || k.CommissionRate >= 1 {
+				return nil, nil, fmt.Errorf("%s: %d decks and a %v commission are invalid", k.Name, k.Decks, k.CommissionRate)
+			}
+			k.commission = casino.Commission{Rate: k.CommissionRate, Rule: casino.CommissionExact}
+			if _, ok := odds[k.Decks]; !ok {
+				player, banker, tie := baccarat.Odds(k.Decks)
+				odds[k.Decks] = [3]float64{player, banker, tie}
+			}
+			player, banker, tie := odds[k.Decks][0], odds[k.Decks][1], odds[k.Decks][2]
+			k.edges = map[string]float64{
+				"Player": banker - player,
+				"Banker": player - banker*(1-k.CommissionRate),
+				"Tie":    (1 - tie) - 8*tie,
+			}
+		case "roulette":
+			if k.Variant == "" {
+				k.Variant = "american"
+			}
+			variant, err := roulette.NewVariant(k.Variant)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", k.Name, err)
+			}
+			k.variant = variant
+			k.wheel = roulette.VariantWheel(variant)
+		default:
+			return nil, nil, fmt.Errorf("%s: unknown game %q, want baccarat or roulette", k.Name, t.Game)
+		}
+		kinds = append(kinds, k)
+	}
+
+	var profiles []*profile
+	for _, p := range f.Players {
+		pr := &profile{Profile: p, bets: map[*kind]roulette.Bet{}}
+		if pr.Name == "" {
+			pr.Name = p.Game + " " + p.On
+		}
+		if p.Share <= 0 || p.Bankroll <= 0 || p.Bet <= 0 || p.Hours <= 0 {
+			return nil, nil, fmt.Errorf("%s: share, bankroll, bet and hours must be positive", pr.Name)
+		}
+		if p.Strategy == "" {
+			pr.Strategy = "flat"
+		}
+		if pr.Strategy != "flat" && pr.Strategy != "martingale" {
+			return nil, nil, fmt.Errorf("%s: unknown strategy %q, want flat or martingale", pr.Name, p.Strategy)
+		}
+		seated := false
+		for _, k := range kinds {
+			if k.Game != p.Game || p.Bet < k.MinBet || p.Bet > k.MaxBet {
+				continue
+			}
+			if p.Game == "baccarat" {
+				switch strings.ToLower(p.On) {
+				case "player", "banker", "tie":
+					pr.On = strings.ToUpper(p.On[:1]) + strings.ToLower(p.On[1:])
+				default:
+					return nil, nil, fmt.Errorf("%s: unknown baccarat bet %q, want Player, Banker or Tie", pr.Name, p.On)
+				}
+			} else {
+				bet, err := roulette.ParseBet(p.On, k.variant)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s at %s: %v", pr.Name, k.Name, err)
+				}
+				pr.bets[k] = bet
+			}
+			seated = true
+		}
+		if !seated {
+			return nil, nil, fmt.Errorf("%s: no %s table takes a %s bet", pr.Name, p.Game, p.Bet)
+		}
+		profiles = append(profiles, pr)
+	}
+	return kinds, profiles, nil
+}
+
+// TableDay is what one table did for the house in a day
+type TableDay struct {
+	Drop    casino.Money // Cash the players bought chips with
+	Handle  casino.Money // Total wagered
+	Win     casino.Money // What the house won from the bets
+	Theo    casino.Money // What the house edge says it should have won from the handle
+	Rounds  int          // Hands or spins with at least one bet
+	Players int          // Players who sat down
+}
+
+// Day is a day of the whole floor
+type Day struct {
+	Tables     [][]TableDay // Each table of each configuration, in order
+	Arrivals   int
+	TurnedAway int // Players who found no free seat at a table taking their bet
+}
+
+// player is a player at a table
+type player struct {
+	profile *profile
+	chips   casino.Money
+	bet     casino.Money // Next stake, before the table limit
+	rounds  int          // Rounds left before they mean to leave
+}
+
+// table is a table in play through the day
+type table struct {
+	kind    *kind
+	day     *TableDay
+	deck    *baccarat.Deck
+	source  roulette.SpinSource
+	players []*player
+	next    float64 // Minute the next round is dealt
+	theo    float64 // Theoretical win in cents, rounded at the end of the day
+}
+
+// leave cashes a player out: the chips go back to the cage, not the table's win
+func (t *table) leave(i int) {
+	t.players = append(t.players[:i], t.players[i+1:]...)
+}
+
+// play deals one round, settling every seated player's bet and letting go
+// of those who are done
+func (t *table) play() {
+	k := t.kind
+	stakes := make([]casino.Money, len(t.players))
+	betting := false
+	for i, p := range t.players {
+		stake := p.bet
+		if stake > k.MaxBet {
+			stake = k.MaxBet
+		}
+		if stake > p.chips {
+			stake = p.chips
+		}
+		if stake >= k.MinBet {
+			stakes[i] = stake
+			betting = true
+		}
+	}
+	if !betting {
+		t.players = t.players[:0]
+		return
+	}
+
+	// Deal the round once for the whole table
+	var winner string
+	var round roulette.Round
+	if k.Game == "baccarat" {
+		if len(t.deck.Cards) < 6 {
+			rng := t.deck.Rand
+			*t.deck = *baccarat.NewShoe(k.Decks)
+			t.deck.Rand = rng
+			t.deck.Shuffle()
+		}
+		playerHand, bankerHand := baccarat.DealInitialHands(t.deck)
+		baccarat.DealThirdCard(t.deck, &playerHand, &bankerHand)
+		winner = baccarat.DetermineWinner(playerHand, bankerHand)
+	} else {
+		round = k.variant.Spin(t.source)
+	}
+	t.day.Rounds++
+
+	for i := len(t.players) - 1; i >= 0; i-- {
+		p := t.players[i]
+		if stakes[i] == 0 {
+			t.leave(i)
+			continue
+		}
+		var settlement casino.Settlement
+		if k.Game == "baccarat" {
+			settlement = baccarat.SettleBet(p.profile.On, stakes[i], winner, &k.commission)
+			t.theo += float64(stakes[i]) * k.edges[p.profile.On]
+		} else {
+			bet := p.profile.bets[k]
+			bet.Amount = stakes[i]
+			settlement = k.variant.Settle(bet, round)
+			t.theo += float64(stakes[i]) * k.variant.HouseEdge(bet)
+		}
+		p.chips += settlement.Net
+		t.day.Handle += settlement.Stake
+		t.day.Win -= settlement.Net
+
+		// Martingale players double after a loss and start over after a win
+		if p.profile.Strategy == "martingale" {
+			switch settlement.Outcome {
+			case casino.Win:
+				p.bet = p.profile.Bet
+			case casino.Lose, casino.HalfLose:
+				if p.bet = stakes[i] * 2; p.bet > k.MaxBet {
+					p.bet = p.profile.Bet
+				}
+			}
+		}
+		p.rounds--
+		if p.rounds <= 0 || p.chips < k.MinBet || (p.profile.WinGoal > 0 && p.chips >= p.profile.Bankroll+p.profile.WinGoal) {
+			t.leave(i)
+		}
+	}
+}
+
+// choose picks a profile for an arriving player by their shares
+func choose(rng *rand.Rand, profiles []*profile, total float64) *profile {
+	x := rng.Float64() * total
+	for _, p := range profiles {
+		if x < p.Share {
+			return p
+		}
+		x -= p.Share
+	}
+	return profiles[len(profiles)-1]
+}
+
+// simulateDay runs one day of the floor from its seed
+func (f *Floor) simulateDay(kinds []*kind, profiles []*profile, seed int64) Day {
+	rng := casino.NewRand(seed)
+	day := Day{Tables: make([][]TableDay, len(kinds))}
+	var tables []*table
+	for i, k := range kinds {
+		day.Tables[i] = make([]TableDay, k.Count)
+		for j := range day.Tables[i] {
+			t := &table{kind: k, day: &day.Tables[i][j], next: rng.Float64() * 60 / float64(k.RoundsPerHour)}
+			tableSeed := casino.SessionSeed(seed, len(tables)+1)
+			if k.Game == "baccarat" {
+				t.deck = baccarat.NewShoe(k.Decks)
+				t.deck.Rand = casino.NewRand(tableSeed)
+				t.deck.Shuffle()
+			} else {
+				t.source = roulette.Seeded(k.wheel, tableSeed)
+			}
+			tables = append(tables, t)
+		}
+	}
+	total := 0.0
+	for _, p := range profiles {
+		total += p.Share
+	}
+
+	// Players arrive at random through the day, each round dealt on its table's clock
+	closing := float64(f.HoursPerDay * 60)
+	arrival := rng.ExpFloat64() / f.ArrivalsPerHour * 60
+	for minute := 0.0; minute < closing; minute++ {
+		for ; arrival < minute+1; arrival += rng.ExpFloat64() / f.ArrivalsPerHour * 60 {
+			day.Arrivals++
+			p := choose(rng, profiles, total)
+
+			// Sit at a random table of the game with a free seat and limits taking the bet
+			var open []*table
+			for _, t := range tables {
+				if t.kind.Game == p.Game && p.Bet >= t.kind.MinBet && p.Bet <= t.kind.MaxBet && len(t.players) < t.kind.Seats {
+					open = append(open, t)
+				}
+			}
+			if len(open) == 0 {
+				day.TurnedAway++
+				continue
+			}
+			t := open[rng.Intn(len(open))]
+			rounds := int(math.Ceil(p.Hours * float64(t.kind.RoundsPerHour)))
+			t.players = append(t.players, &player{profile: p, chips: p.Bankroll, bet: p.Bet, rounds: rounds})
+			t.day.Drop += p.Bankroll
+			t.day.Players++
+		}
+		for _, t := range tables {
+			for ; t.next < minute+1; t.next += 60 / float64(t.kind.RoundsPerHour) {
+				if len(t.players) > 0 {
+					t.play()
+				}
+			}
+		}
+	}
+
+	// Everyone still playing cashes out at closing, which leaves the win as it is
+	for _, t := range tables {
+		t.day.Theo = casino.Money(math.Round(t.theo))
+	}
+	return day
+}
+
+// Run simulates the floor for a number of days, each day on its own
+// goroutine with a seed derived from seed, so runs with the same seed see
+// the same days
+func (f *Floor) Run(days int, seed int64) ([]Day, error) {
+	kinds, profiles, err := f.prepare()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Day, days)
+	var wg sync.WaitGroup
+	for d := 0; d < days; d++ {
+		wg.Add(1)
+		go func(d int) {
+			defer wg.Done()
+			results[d] = f.simulateDay(kinds, profiles, casino.SessionSeed(seed, d))
+		}(d)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// Summary is the house's view of a group of tables over every day
+type Summary struct {
+	Name        string
+	Tables      int
+	Drop        casino.Money
+	Handle      casino.Money
+	Win         casino.Money
+	Theo        casino.Money
+	Hold        float64      // Win as a share of the drop
+	WinPerHour  casino.Money // Win per table per hour open
+	DailyMean   float64      // Mean win of one table in a day, in dollars
+	DailyStdDev float64      // Standard deviation of one table's daily win
+	LosingDays  float64      // Share of table days the house lost
+	Players     int
+	Rounds      int
+}
+
+// add totals the days of one table into the summary
+func (s *Summary) add(days []TableDay, dailyWins *[]float64) {
+	for _, d := range days {
+		s.Drop += d.Drop
+		s.Handle += d.Handle
+		s.Win += d.Win
+		s.Theo += d.Theo
+		s.Players += d.Players
+		s.Rounds += d.Rounds
+		*dailyWins = append(*dailyWins, d.Win.Float())
+	}
+}
+
+// finish works out the rates from the totals
+func (s *Summary) finish(dailyWins []float64, tableHours int) {
+	if s.Drop > 0 {
+		s.Hold = s.Win.Float() / s.Drop.Float()
+	}
+	if tableHours > 0 {
+		s.WinPerHour = s.Win / casino.Money(tableHours)
+	}
+	if len(dailyWins) == 0 {
+		return
+	}
+	losing := 0
+	for _, w := range dailyWins {
+		s.DailyMean += w
+		if w < 0 {
+			losing++
+		}
+	}
+	s.DailyMean /= float64(len(dailyWins))
+	s.LosingDays = float64(losing) / float64(len(dailyWins))
+	if len(dailyWins) > 1 {
+		sumOfSquares := 0.0
+		for _, w := range dailyWins {
+			sumOfSquares += (w - s.DailyMean) * (w - s.DailyMean)
+		}
+		s.DailyStdDev = math.Sqrt(sumOfSquares / float64(len(dailyWins)-1))
+	}
+}
+
+// Summarize totals the days for each group of tables, then for the whole
+// floor, whose daily figures are of the floor's win rather than one table's
+func (f *Floor) Summarize(days []Day) []Summary {
+	summaries := make([]Summary, len(f.Tables)+1)
+	floor := &summaries[len(f.Tables)]
+	floor.Name = "Floor"
+	var floorWins []float64
+	for i, t := range f.Tables {
+		s := &summaries[i]
+		s.Name, s.Tables = t.Name, t.Count
+		if s.Name == "" {
+			s.Name = t.Game
+		}
+		var dailyWins []float64
+		for _, day := range days {
+			s.add(day.Tables[i], &dailyWins)
+		}
+		s.finish(dailyWins, len(days)*t.Count*f.HoursPerDay)
+		floor.Tables += t.Count
+	}
+	for _, day := range days {
+		var win casino.Money
+		for _, tables := range day.Tables {
+			var ignored []float64
+			floor.add(tables, &ignored)
+			for _, t := range tables {
+				win += t.Win
+			}
+		}
+		floorWins = append(floorWins, win.Float())
+	}
+	floor.finish(floorWins, len(days)*floor.Tables*f.HoursPerDay)
+	return summaries
+}
@@ -0,0 +1,158 @@
+package floor
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+func TestRunRepeatsWithSeed(t *testing.T) {
+	f := Default()
+	f.HoursPerDay = 4
+	a, err := f.Run(3, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := f.Run(3, 7)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("seed 7 simulated different days")
+	}
+	if a[0].Arrivals == 0 || len(a[0].Tables) != len(f.Tables) || len(a[0].Tables[0]) != f.Tables[0].Count {
+		t.Errorf("day = %+v", a[0])
+	}
+}
+
+func TestHoldAndTheo(t *testing.T) {
+	f := &Floor{
+		Tables:          []TableConfig{{Name: "Roulette", Game: "roulette", Count: 2, MinBet: casino.Dollars(10), MaxBet: casino.Dollars(500), RoundsPerHour: 40, Seats: 8}},
+		Players:         []Profile{{Share: 1, Game: "roulette", Bankroll: casino.Dollars(200), Bet: casino.Dollars(10), On: "red", Hours: 1}},
+		ArrivalsPerHour: 10,
+		HoursPerDay:     24,
+	}
+	days, err := f.Run(20, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summaries := f.Summarize(days)
+	if len(summaries) != 2 || summaries[1].Name != "Floor" {
+		t.Fatalf("summaries = %+v", summaries)
+	}
+	s := summaries[0]
+
+	// Red on an American wheel gives the house 2/38 of every bet
+	edge := 2.0 / 38
+	if math.Abs(s.Theo.Float()-s.Handle.Float()*edge) > float64(len(days)*2) {
+		t.Errorf("theo %s, want %.4f of the %s handle", s.Theo, edge, s.Handle)
+	}
+	if got := s.Win.Float() / s.Handle.Float(); math.Abs(got-edge) > 0.015 {
+		t.Errorf("win is %.4f of the handle, want about %.4f", got, edge)
+	}
+	if s.Hold != s.Win.Float()/s.Drop.Float() || s.Drop != casino.Dollars(200)*casino.Money(s.Players) {
+		t.Errorf("hold %v of a %s drop from %d players", s.Hold, s.Drop, s.Players)
+	}
+	if s.WinPerHour != s.Win/casino.Money(20*2*24) || s.DailyStdDev <= 0 {
+		t.Errorf("summary = %+v", s)
+	}
+	if floor := summaries[1]; floor.Win != s.Win || floor.Tables != 2 {
+		t.Errorf("floor = %+v, want the one group's totals", floor)
+	}
+}
+
+func TestBaccaratTheo(t *testing.T) {
+	f := &Floor{
+		Tables:          []TableConfig{{Name: "Baccarat", Game: "baccarat", Count: 1, Decks: 8, CommissionRate: 0.05, MinBet: casino.Dollars(25), MaxBet: casino.Dollars(5000), RoundsPerHour: 70, Seats: 7}},
+		Players:         []Profile{{Share: 1, Game: "baccarat", Bankroll: casino.Dollars(1000), Bet: casino.Dollars(25), On: "Banker", Hours: 1}},
+		ArrivalsPerHour: 4,
+		HoursPerDay:     8,
+	}
+	days, err := f.Run(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Banker from 8 decks gives the house 1.0579% of every bet
+	s := f.Summarize(days)[0]
+	if got := s.Theo.Float() / s.Handle.Float(); s.Handle == 0 || math.Abs(got-0.010579) > 0.00005 {
+		t.Errorf("theo is %.6f of the %s handle, want 0.010579", got, s.Handle)
+	}
+}
+
+func TestInvalidFloor(t *testing.T) {
+	cases := map[string]func(f *Floor){
+		"arrivals":  func(f *Floor) { f.ArrivalsPerHour = 0 },
+		"hours":     func(f *Floor) { f.HoursPerDay = 25 },
+		"game":      func(f *Floor) { f.Tables[0].Game = "poker" },
+		"limits":    func(f *Floor) { f.Tables[0].MaxBet = 1 },
+		"variant":   func(f *Floor) { f.Tables[2].Variant = "triple_zero" },
+		"strategy":  func(f *Floor) { f.Players[0].Strategy = "paroli" },
+		"bet":       func(f *Floor) { f.Players[0].On = "Dragon" },
+		"roulette":  func(f *Floor) { f.Players[4].On = "street 1-2-4" },
+		"no tables": func(f *Floor) { f.Players[3].Bet = casino.Dollars(50000) },
+	}
+	for name, change := range cases {
+		f := Default()
+		change(f)
+		if _, err := f.Run(1, 1); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "floor.yaml")
+	yaml := `
+tables:
+  - {name: Mini baccarat, game: baccarat, count: 3, decks: 6, min_bet: 10, max_bet: 1000, rounds_per_hour: 80, seats: 7}
+players:
+  - {name: Punter, share: 1, game: baccarat, bankroll: 250.50, bet: 10, on: banker, hours: 2}
+arrivals_per_hour: 5
+hours_per_day: 12
+`
+	os.WriteFile(path, []byte(yaml), 0644)
+	f, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Tables[0].MaxBet != casino.Dollars(1000) || f.Players[0].Bankroll != casino.Dollars(250)+50 || f.HoursPerDay != 12 {
+		t.Errorf("floor = %+v", f)
+	}
+	if _, err := f.Run(1, 1); err != nil {
+		t.Error(err)
+	}
+
+	os.WriteFile(path, []byte(strings.Replace(yaml, "seats", "chairs", 1)), 0644)
+	if _, err := Load(path); err == nil {
+		t.Error("unknown setting accepted")
+	}
+}
+
+func TestMiniTheo(t *testing.T) {
+	f := &Floor{
+		Tables:          []TableConfig{{Name: "Mini", Game: "roulette", Count: 2, Variant: "mini", MinBet: casino.Dollars(10), MaxBet: casino.Dollars(500), RoundsPerHour: 40, Seats: 8}},
+		Players:         []Profile{{Share: 1, Game: "roulette", Bankroll: casino.Dollars(200), Bet: casino.Dollars(10), On: "red", Hours: 1}},
+		ArrivalsPerHour: 10,
+		HoursPerDay:     24,
+	}
+	days, err := f.Run(20, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := f.Summarize(days)[0]
+
+	// Red covers 6 of the 13 pockets of a mini wheel and pays even money
+	edge := 1.0 / 13
+	if got := s.Theo.Float() / s.Handle.Float(); s.Handle == 0 || math.Abs(got-edge) > 0.0001 {
+		t.Errorf("theo is %.4f of the %s handle, want %.4f", got, s.Handle, edge)
+	}
+	if got := s.Win.Float() / s.Handle.Float(); math.Abs(got-edge) > 0.015 {
+		t.Errorf("win is %.4f of the handle, want about the %.4f theo", got, edge)
+	}
+	if s.Hold != s.Win.Float()/s.Drop.Float() {
+		t.Errorf("hold %v of a %s drop, want the %s win over it", s.Hold, s.Drop, s.Win)
+	}
+}
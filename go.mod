@@ -2,7 +2,13 @@ module github.com/BryceWayne/casino
 
 go 1.20
 
-require github.com/cheggaaa/pb/v3 v3.1.5
+require (
+	github.com/cheggaaa/pb/v3 v3.1.5
+	github.com/gorilla/websocket v1.5.3
+	google.golang.org/grpc v1.64.1
+	google.golang.org/protobuf v1.34.1
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/VividCortex/ewma v1.2.0 // indirect
@@ -11,5 +17,8 @@ require (
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
-	golang.org/x/sys v0.6.0 // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
 )
@@ -0,0 +1,69 @@
+package casino
+
+import (
+	"fmt"
+	"math"
+)
+
+// Kelly sizes each bet as a fraction of the bankroll from the edge and the
+// variance of the bet. Full Kelly stakes edge/variance of the bankroll,
+// which maximises long run growth; fractional Kelly stakes less to cut the
+// drawdowns, and a cap bounds the stake whatever the estimates say.
+type Kelly struct {
+	Edge     float64 // Expected profit per unit staked
+	Variance float64 // Variance of the profit per unit staked
+	Fraction float64 // Share of the full Kelly stake, 1 for full Kelly
+	Cap      float64 // Largest share of the bankroll to stake, 0 for no cap
+}
+
+// KellyBet returns the edge and variance per unit staked of a bet that
+// wins with probability p and pays payout to one
+func KellyBet(p, payout float64) (float64, float64) {
+	edge := p*payout - (1 - p)
+	variance := p*payout*payout + (1 - p) - edge*edge
+	return edge, variance
+}
+
+// Validate checks the fraction and cap
+func (k Kelly) Validate() error {
+	if k.Fraction <= 0 || k.Fraction > 1 {
+		return fmt.Errorf("kelly fraction %g must be above 0 and at most 1", k.Fraction)
+	}
+	if k.Cap < 0 || k.Cap > 1 {
+		return fmt.Errorf("kelly cap %g must be between 0 and 1", k.Cap)
+	}
+	if k.Variance <= 0 {
+		return fmt.Errorf("kelly variance %g must be positive", k.Variance)
+	}
+	return nil
+}
+
+// Share returns the share of the bankroll to stake, 0 without an edge
+func (k Kelly) Share() float64 {
+	if k.Edge <= 0 || k.Variance <= 0 {
+		return 0
+	}
+	share := k.Edge / k.Variance * k.Fraction
+	if k.Cap > 0 && share > k.Cap {
+		share = k.Cap
+	}
+	return share
+}
+
+// Stake returns the amount to bet from the bankroll, rounded down to the cent
+func (k Kelly) Stake(bankroll Money) Money {
+	if bankroll <= 0 {
+		return 0
+	}
+	return Money(math.Floor(float64(bankroll) * k.Share()))
+}
+
+// Growth returns the expected log growth of the bankroll per bet, the
+// quantity Kelly sizing maximises
+func (k Kelly) Growth() float64 {
+	share := k.Share()
+	if share == 0 {
+		return 0
+	}
+	return share*k.Edge - share*share*k.Variance/2
+}
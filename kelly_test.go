@@ -0,0 +1,47 @@
+package casino
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKellyBet(t *testing.T) {
+	// An even-money bet won 55% of the time has a 10% edge and stakes about 10%
+	edge, variance := KellyBet(0.55, 1)
+	if math.Abs(edge-0.1) > 1e-12 || math.Abs(variance-0.99) > 1e-12 {
+		t.Fatalf("got edge %g, variance %g", edge, variance)
+	}
+	kelly := Kelly{Edge: edge, Variance: variance, Fraction: 1}
+	if got := kelly.Stake(Dollars(1000)); got != 10101 {
+		t.Errorf("full Kelly: got %s", got)
+	}
+	kelly.Fraction = 0.5
+	if got := kelly.Stake(Dollars(1000)); got != 5050 {
+		t.Errorf("half Kelly: got %s", got)
+	}
+	kelly.Cap = 0.02
+	if got := kelly.Stake(Dollars(1000)); got != Dollars(20) {
+		t.Errorf("capped Kelly: got %s", got)
+	}
+}
+
+func TestKellyWithoutEdge(t *testing.T) {
+	edge, variance := KellyBet(18.0/37, 1)
+	kelly := Kelly{Edge: edge, Variance: variance, Fraction: 1}
+	if kelly.Stake(Dollars(1000)) != 0 || kelly.Growth() != 0 {
+		t.Error("a bet without an edge should not be staked")
+	}
+}
+
+func TestDrawdown(t *testing.T) {
+	d := Drawdown{Peak: Dollars(100)}
+	for _, balance := range []Money{Dollars(150), Dollars(90), Dollars(120), Dollars(200), Dollars(180)} {
+		d.Record(balance)
+	}
+	if math.Abs(d.Max-0.4) > 1e-12 {
+		t.Errorf("got max drawdown %g, want 0.4", d.Max)
+	}
+	if got := Percentile([]float64{4, 1, 3, 2}, 50); got != 2.5 {
+		t.Errorf("median: got %g", got)
+	}
+}
@@ -0,0 +1,155 @@
+package casino
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Money is an amount in cents. Fractional amounts such as commission are
+// rounded to the cent, or to a coarser minimum unit, instead of truncated.
+type Money int64
+
+const (
+	Cent   Money = 1
+	Dollar Money = 100 * Cent
+
+	// MaxMoney is the largest amount ParseMoney accepts, a trillion dollars,
+	// which leaves room to add up many amounts without overflowing
+	MaxMoney Money = 1_000_000_000_000 * Dollar
+)
+
+// Dollars returns a whole number of dollars
+func Dollars(n int) Money {
+	return Money(n) * Dollar
+}
+
+// ParseMoney parses an amount in dollars such as "25", "23.75" or "$1.5"
+func ParseMoney(s string) (Money, error) {
+	text := strings.TrimPrefix(strings.TrimSpace(s), "$")
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	whole, fraction := text, ""
+	if i := strings.Index(text, "."); i >= 0 {
+		whole, fraction = text[:i], text[i+1:]
+	}
+	if whole == "" && fraction == "" || len(fraction) > 2 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	dollars, err := strconv.ParseInt(strings.ReplaceAll(whole, "_", ""), 10, 64)
+	if err != nil || dollars < 0 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil || cents < 0 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if dollars > int64(MaxMoney/Dollar) || Money(dollars)*Dollar+Money(cents) > MaxMoney {
+		return 0, fmt.Errorf("amount %q is over the maximum of %s", s, MaxMoney)
+	}
+
+	m := Money(dollars)*Dollar + Money(cents)
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+// Add returns the sum of the amounts, and false if it overflows
+func (m Money) Add(n Money) (Money, bool) {
+	sum := m + n
+	return sum, (n >= 0) == (sum >= m)
+}
+
+// String formats the amount in dollars, showing cents only when there are any
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	if m%Dollar == 0 {
+		return fmt.Sprintf("%s$%d", sign, m/Dollar)
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, m/Dollar, m%Dollar)
+}
+
+// Float returns the amount in dollars, for statistics
+func (m Money) Float() float64 {
+	return float64(m) / float64(Dollar)
+}
+
+// Scale multiplies the amount by a rate, rounding to the nearest cent
+func (m Money) Scale(rate float64) Money {
+	return Money(math.Round(float64(m) * rate))
+}
+
+// Floor rounds the amount down to a multiple of unit
+func (m Money) Floor(unit Money) Money {
+	if unit <= 0 {
+		return m
+	}
+	r := m % unit
+	if r < 0 {
+		r += unit
+	}
+	return m - r
+}
+
+// Ceil rounds the amount up to a multiple of unit
+func (m Money) Ceil(unit Money) Money {
+	if unit <= 0 {
+		return m
+	}
+	floor := m.Floor(unit)
+	if floor == m {
+		return m
+	}
+	return floor + unit
+}
+
+// Set parses the amount from a command-line flag
+func (m *Money) Set(s string) error {
+	value, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// MoneyFlag defines a flag holding an amount in dollars, like fs.Int
+func MoneyFlag(fs *flag.FlagSet, name string, value Money, usage string) *Money {
+	m := value
+	fs.Var(&m, name, usage)
+	return &m
+}
+
+// MarshalJSON writes the amount as a number of dollars
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strings.Replace(m.String(), "$", "", 1)), nil
+}
+
+// UnmarshalJSON reads an amount written as a number of dollars
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	return m.Set(number.String())
+}
+
+// UnmarshalYAML reads an amount written as a number of dollars
+func (m *Money) UnmarshalYAML(value *yaml.Node) error {
+	return m.Set(value.Value)
+}
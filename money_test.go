@@ -0,0 +1,104 @@
+package casino
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]Money{"25": 2500, "23.75": 2375, "$1.5": 150, "-3.05": -305, ".25": 25, "10_000": 1_000_000}
+	for text, want := range cases {
+		got, err := ParseMoney(text)
+		if err != nil || got != want {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", text, got, err, want)
+		}
+	}
+	if got, err := ParseMoney("1_000_000_000_000"); err != nil || got != MaxMoney {
+		t.Errorf("ParseMoney of the maximum = %d, %v, want %d", got, err, MaxMoney)
+	}
+	for _, text := range []string{"", "1.234", "abc", "1.x", "$", "1_000_000_000_000.01", "92233720368547759", "-92233720368547759", "99999999999999999999"} {
+		if _, err := ParseMoney(text); err == nil {
+			t.Errorf("ParseMoney(%q) should fail", text)
+		}
+	}
+}
+
+func TestMoneyAdd(t *testing.T) {
+	if sum, ok := MaxMoney.Add(MaxMoney); !ok || sum != 2*MaxMoney {
+		t.Errorf("MaxMoney.Add(MaxMoney) = %d, %v", sum, ok)
+	}
+	if sum, ok := Money(-5).Add(3); !ok || sum != -2 {
+		t.Errorf("-5 + 3 = %d, %v", sum, ok)
+	}
+	big := Money(1<<63 - 1)
+	if _, ok := big.Add(1); ok {
+		t.Error("adding a cent to the largest amount should overflow")
+	}
+	if _, ok := (-big - 1).Add(-1); ok {
+		t.Error("taking a cent from the smallest amount should overflow")
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	cases := map[Money]string{2500: "$25", 2375: "$23.75", -305: "-$3.05", 5: "$0.05"}
+	for m, want := range cases {
+		if got := m.String(); got != want {
+			t.Errorf("%d formats as %q, want %q", int64(m), got, want)
+		}
+	}
+}
+
+func TestMoneyRounding(t *testing.T) {
+	if got := Money(2375).Floor(Dollar); got != 2300 {
+		t.Errorf("floor: got %d", got)
+	}
+	if got := Money(125).Ceil(25 * Cent); got != 125 {
+		t.Errorf("ceil of a multiple: got %d", got)
+	}
+	if got := Money(126).Ceil(25 * Cent); got != 150 {
+		t.Errorf("ceil: got %d", got)
+	}
+	if got := Money(-150).Floor(Dollar); got != -200 {
+		t.Errorf("floor of a negative amount: got %d", got)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(struct{ Balance Money }{2375})
+	if err != nil || string(data) != `{"Balance":23.75}` {
+		t.Fatalf("marshal: got %s, %v", data, err)
+	}
+	var decoded struct{ Balance Money }
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Balance != 2375 {
+		t.Errorf("unmarshal: got %d, %v", decoded.Balance, err)
+	}
+}
+
+func TestCommission(t *testing.T) {
+	// 5% commission on a $25 Banker win is $1.25
+	exact := Commission{Rate: 1 - 0.95, Rule: CommissionExact}
+	if got := exact.Charge(Dollars(25)); got != 2375 {
+		t.Errorf("exact: got %s", got)
+	}
+
+	round := Commission{Rate: 0.05, Rule: CommissionRound, Unit: Dollar}
+	if got := round.Charge(Dollars(25)); got != Dollars(23) {
+		t.Errorf("round: got %s", got)
+	}
+
+	owed := Commission{Rate: 0.05, Rule: CommissionOwed, Unit: 25 * Cent}
+	if got := owed.Charge(Dollars(25)); got != Dollars(25) {
+		t.Errorf("owed: paid %s, want the full win", got)
+	}
+	owed.Charge(Dollars(15))
+	if owed.Owed != 200 {
+		t.Errorf("owed: tracked %s, want $2", owed.Owed)
+	}
+	if got := owed.Collect(); got != 200 || owed.Owed != 0 {
+		t.Errorf("collect: got %s, %s left", got, owed.Owed)
+	}
+	owed.Charge(Dollars(3))
+	if got := owed.Collect(); got != 25 {
+		t.Errorf("collect rounds up to the unit: got %s", got)
+	}
+}
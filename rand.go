@@ -0,0 +1,37 @@
+package casino
+
+import "math/rand"
+
+// splitMix is the SplitMix64 generator: tiny and fast, so every simulated
+// session can have a generator of its own
+type splitMix struct {
+	state uint64
+}
+
+func (s *splitMix) Seed(seed int64) { s.state = uint64(seed) }
+
+func (s *splitMix) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *splitMix) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+// NewRand returns a generator seeded with seed. Sessions given the same
+// seed draw the same numbers, which is how strategies and parameter
+// settings are compared with common random numbers.
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(&splitMix{state: uint64(seed)})
+}
+
+// SessionSeed returns the seed of one session of a run. Mixing the run's
+// seed with the session number keeps runs with nearby seeds, such as a
+// training and a validation seed, from sharing sessions.
+func SessionSeed(seed int64, session int) int64 {
+	s := splitMix{state: uint64(seed)}
+	s.state ^= s.Uint64() + uint64(session)
+	return int64(s.Uint64())
+}
@@ -0,0 +1,32 @@
+package casino
+
+import "fmt"
+
+// Rebate is a player programme paid when a session ends: a loss rebate
+// returns a share of the session's net loss, and a rolling-chip commission
+// pays a share of the turnover, everything staked over the session
+type Rebate struct {
+	LossRate    float64 // Share of a net loss paid back
+	RollingRate float64 // Share of the turnover paid back, win or lose
+}
+
+// Validate checks that both rates are shares below one
+func (r Rebate) Validate() error {
+	if r.LossRate < 0 || r.LossRate >= 1 {
+		return fmt.Errorf("loss rebate %g must be at least 0 and below 1", r.LossRate)
+	}
+	if r.RollingRate < 0 || r.RollingRate >= 1 {
+		return fmt.Errorf("rolling commission %g must be at least 0 and below 1", r.RollingRate)
+	}
+	return nil
+}
+
+// Pay returns the rebate on a session that ended net up or down with the
+// turnover staked, each part rounded to the cent
+func (r Rebate) Pay(net, turnover Money) Money {
+	var paid Money
+	if net < 0 {
+		paid += (-net).Scale(r.LossRate)
+	}
+	return paid + turnover.Scale(r.RollingRate)
+}
@@ -0,0 +1,25 @@
+package casino
+
+import "testing"
+
+func TestRebatePay(t *testing.T) {
+	r := Rebate{LossRate: 0.1, RollingRate: 0.008}
+	for _, c := range []struct {
+		net, turnover, want Money
+	}{
+		{Dollars(-1000), Dollars(50_000), Dollars(500)},
+		{Dollars(1000), Dollars(50_000), Dollars(400)},
+		{0, 0, 0},
+		{-333, 0, 33},
+	} {
+		if got := r.Pay(c.net, c.turnover); got != c.want {
+			t.Errorf("Pay(%s, %s) = %s, want %s", c.net, c.turnover, got, c.want)
+		}
+	}
+	if err := (Rebate{LossRate: 1}).Validate(); err == nil {
+		t.Error("a loss rebate of 100% should be refused")
+	}
+	if err := r.Validate(); err != nil {
+		t.Error(err)
+	}
+}
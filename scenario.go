@@ -0,0 +1,314 @@
+package casino
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario describes a simulation in a YAML or JSON file: the game and its
+// variant, the table rules, the bet layout, the progression, the bankroll
+// and session limits, and the outputs. Each command takes the settings it
+// understands from the file and flags given on the command line override
+// them.
+type Scenario struct {
+	Game        string          `json:"game" yaml:"game"`         // baccarat or roulette
+	Strategy    string          `json:"strategy" yaml:"strategy"` // Preset of the roulette simulator: fib, martingale or mixed
+	Variant     string          `json:"variant" yaml:"variant"`   // Roulette variant: european, american, la_partage, mini, double_ball or lightning
+	Table       TableRules      `json:"table" yaml:"table"`
+	Bets        []BetSpec       `json:"bets" yaml:"bets"`
+	Progression ProgressionSpec `json:"progression" yaml:"progression"`
+	Rebate      RebateSpec      `json:"rebate" yaml:"rebate"`
+	Bankroll    Money           `json:"bankroll" yaml:"bankroll"`
+	StopLoss    Money           `json:"stop_loss" yaml:"stop_loss"`
+	WinGoal     Money           `json:"win_goal" yaml:"win_goal"`     // Profit that ends a session
+	MaxRounds   int             `json:"max_rounds" yaml:"max_rounds"` // Hands or spins after which a session stops
+	Simulations int             `json:"simulations" yaml:"simulations"`
+	Output      OutputSpec      `json:"output" yaml:"output"`
+}
+
+// TableRules are the rules and limits of the table
+type TableRules struct {
+	Decks          int     `json:"decks" yaml:"decks"`
+	Commission     string  `json:"commission" yaml:"commission"`           // exact, round or owed
+	CommissionRate float64 `json:"commission_rate" yaml:"commission_rate"` // Share of a Banker win kept by the house
+	Chips          []Money `json:"chips" yaml:"chips"`
+	ChipUnit       Money   `json:"chip_unit" yaml:"chip_unit"`
+	Rounding       string  `json:"rounding" yaml:"rounding"` // up, down or refuse
+	InsideMin      Money   `json:"inside_min" yaml:"inside_min"`
+	InsideMax      Money   `json:"inside_max" yaml:"inside_max"`
+	OutsideMin     Money   `json:"outside_min" yaml:"outside_min"`
+	OutsideMax     Money   `json:"outside_max" yaml:"outside_max"`
+	TableMin       Money   `json:"table_min" yaml:"table_min"`
+	TableMax       Money   `json:"table_max" yaml:"table_max"`
+	AtLimit        string  `json:"at_limit" yaml:"at_limit"` // cap, quit or restart
+	Bias           string  `json:"bias" yaml:"bias"`
+	Physics        string  `json:"physics" yaml:"physics"`
+}
+
+// BetSpec is one bet of the layout, written like "dozen 3" or "split 17-20"
+type BetSpec struct {
+	Bet    string `json:"bet" yaml:"bet"`
+	Amount Money  `json:"amount" yaml:"amount"`
+}
+
+// ProgressionSpec is how the stakes are sized
+type ProgressionSpec struct {
+	System        string  `json:"system" yaml:"system"`
+	Unit          Money   `json:"unit" yaml:"unit"`
+	Steps         []Money `json:"steps" yaml:"steps"`
+	MaxSteps      int     `json:"max_steps" yaml:"max_steps"`
+	ResetOnWin    *bool   `json:"reset_on_win" yaml:"reset_on_win"`
+	AtEnd         string  `json:"at_end" yaml:"at_end"`
+	KellyFraction float64 `json:"kelly_fraction" yaml:"kelly_fraction"`
+	KellyCap      float64 `json:"kelly_cap" yaml:"kelly_cap"`
+	KellyBet      string  `json:"kelly_bet" yaml:"kelly_bet"`
+	Edge          float64 `json:"edge" yaml:"edge"`
+	Variance      float64 `json:"variance" yaml:"variance"`
+}
+
+// RebateSpec is the rebate program paid when a baccarat session ends
+type RebateSpec struct {
+	LossRate    float64 `json:"loss_rate" yaml:"loss_rate"`       // Share of the net loss paid back
+	RollingRate float64 `json:"rolling_rate" yaml:"rolling_rate"` // Share of the turnover paid back
+}
+
+// OutputSpec lists the files a run writes
+type OutputSpec struct {
+	History *string `json:"history" yaml:"history"` // Game history of every hand, empty to skip it
+	Results string  `json:"results" yaml:"results"` // CSV with one row per session
+}
+
+// LoadScenario reads a scenario from a .json file, or from YAML otherwise.
+// Unknown settings are errors so that a misspelt key is not ignored.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Scenario
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&s)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &s, nil
+}
+
+// amount formats an amount the way a money flag parses it
+func amount(m Money) string {
+	return strings.Replace(m.String(), "$", "", 1)
+}
+
+// amounts formats a comma separated list of amounts
+func amounts(list []Money) string {
+	fields := make([]string, len(list))
+	for i, m := range list {
+		fields[i] = amount(m)
+	}
+	return strings.Join(fields, ",")
+}
+
+// Settings returns the scenario as command-line flag values, leaving out
+// everything the file does not set
+func (s *Scenario) Settings() map[string]string {
+	settings := map[string]string{}
+	set := func(name, value string) {
+		if value != "" {
+			settings[name] = value
+		}
+	}
+	setInt := func(name string, value int) {
+		if value != 0 {
+			settings[name] = strconv.Itoa(value)
+		}
+	}
+	setFloat := func(name string, value float64) {
+		if value != 0 {
+			settings[name] = strconv.FormatFloat(value, 'g', -1, 64)
+		}
+	}
+	setMoney := func(name string, value Money) {
+		if value != 0 {
+			settings[name] = amount(value)
+		}
+	}
+
+	switch s.Variant {
+	case "european":
+		set("european", "true")
+		set("lapartage", "false")
+	case "american":
+		set("european", "false")
+		set("lapartage", "false")
+	case "la_partage":
+		set("european", "true")
+		set("lapartage", "true")
+	}
+	set("variant", s.Variant)
+	set("strategy", s.Strategy)
+
+	t := s.Table
+	setInt("decks", t.Decks)
+	set("commission", t.Commission)
+	if t.CommissionRate != 0 {
+		setFloat("houseEdge", 1-t.CommissionRate)
+	}
+	set("chips", amounts(t.Chips))
+	setMoney("chipunit", t.ChipUnit)
+	setMoney("chip", t.ChipUnit)
+	set("rounding", t.Rounding)
+	setMoney("insidemin", t.InsideMin)
+	setMoney("insidemax", t.InsideMax)
+	setMoney("outsidemin", t.OutsideMin)
+	setMoney("outsidemax", t.OutsideMax)
+	setMoney("tablemin", t.TableMin)
+	setMoney("tablemax", t.TableMax)
+	setMoney("tablelimit", t.TableMax)
+	set("atlimit", t.AtLimit)
+	set("bias", t.Bias)
+	set("physics", t.Physics)
+
+	bets := make([]string, len(s.Bets))
+	for i, bet := range s.Bets {
+		bets[i] = bet.Bet
+		if bet.Amount != 0 {
+			bets[i] += ":" + amount(bet.Amount)
+		}
+	}
+	set("bets", strings.Join(bets, ","))
+
+	p := s.Progression
+	set("progression", p.System)
+	setMoney("bet", p.Unit)
+	set("steps", amounts(p.Steps))
+	setInt("maxsteps", p.MaxSteps)
+	if p.ResetOnWin != nil {
+		set("resetonwin", strconv.FormatBool(*p.ResetOnWin))
+	}
+	set("atend", p.AtEnd)
+	setFloat("kelly", p.KellyFraction)
+	setFloat("kellycap", p.KellyCap)
+	set("kellybet", p.KellyBet)
+	setFloat("edge", p.Edge)
+	setFloat("variance", p.Variance)
+	setFloat("lossrebate", s.Rebate.LossRate)
+	setFloat("rolling", s.Rebate.RollingRate)
+
+	setMoney("balance", s.Bankroll)
+	setMoney("stoploss", s.StopLoss)
+	setMoney("profit", s.WinGoal)
+	setInt("maxspins", s.MaxRounds)
+	setInt("maxhands", s.MaxRounds)
+	setInt("simulations", s.Simulations)
+	if s.Output.History != nil {
+		settings["history"] = *s.Output.History
+	}
+	set("results", s.Output.Results)
+	return settings
+}
+
+// ApplyConfig loads a scenario file for a game into every flag of the set
+// that was not given on the command line. Settings the command has no flag
+// for are skipped, so one file can drive several commands. Call it after
+// parsing the flags.
+func ApplyConfig(fs *flag.FlagSet, path, game string) error {
+	if path == "" {
+		return nil
+	}
+	s, err := LoadScenario(path)
+	if err != nil {
+		return err
+	}
+	if s.Game != "" && s.Game != game {
+		return fmt.Errorf("%s describes a %s scenario, not %s", path, s.Game, game)
+	}
+	if err := ApplyScenario(fs, s); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+// ApplyScenario sets every flag of the set that was not given on the
+// command line from a scenario, skipping settings the set has no flag for
+func ApplyScenario(fs *flag.FlagSet, s *Scenario) error {
+	if err := s.checkVariant(fs); err != nil {
+		return err
+	}
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for name, value := range s.Settings() {
+		if given[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", name, value, err)
+		}
+	}
+	return nil
+}
+
+// checkVariant rejects an unknown roulette variant, and a variant other
+// than the classic wheels for a command that only has -european and
+// -lapartage to choose its wheel
+func (s *Scenario) checkVariant(fs *flag.FlagSet) error {
+	switch s.Variant {
+	case "", "european", "american", "la_partage":
+		return nil
+	case "mini", "double_ball", "lightning":
+		if fs.Lookup("variant") != nil || fs.Lookup("european") == nil {
+			return nil
+		}
+		return fmt.Errorf("%s cannot play the %s variant, want european, american or la_partage", fs.Name(), s.Variant)
+	}
+	return fmt.Errorf("unknown variant %q, want european, american, la_partage, mini, double_ball or lightning", s.Variant)
+}
+
+// SessionResult is one session of a simulation, as written to a results file
+type SessionResult struct {
+	Outcome     string // won, lost, capped or unfinished
+	Balance     Money
+	Rounds      int     // Hands or spins played
+	MaxDrawdown float64 // Largest fall of the balance from its peak, as a share of the peak
+}
+
+// WriteResults writes one CSV row per session
+func WriteResults(path string, sessions []SessionResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"session", "outcome", "balance", "rounds", "max_drawdown"})
+	for i, s := range sessions {
+		writer.Write([]string{
+			strconv.Itoa(i + 1),
+			s.Outcome,
+			amount(s.Balance),
+			strconv.Itoa(s.Rounds),
+			strconv.FormatFloat(s.MaxDrawdown, 'f', 4, 64),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,114 @@
+package casino
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScenarioSettings(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fib.yaml")
+	data := `game: roulette
+variant: la_partage
+table:
+  chips: [5, 25]
+  outside_max: 2500
+bets:
+  - bet: dozen 3
+    amount: 12.5
+  - bet: high
+progression:
+  system: fibonacci
+  reset_on_win: false
+rebate:
+  loss_rate: 0.1
+bankroll: 25000
+win_goal: 5000
+max_rounds: 300
+output:
+  history: ""
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadScenario(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"european": "true", "lapartage": "true", "variant": "la_partage",
+		"chips": "5,25", "outsidemax": "2500", "bets": "dozen 3:12.50,high",
+		"progression": "fibonacci", "resetonwin": "false",
+		"balance": "25000", "profit": "5000", "maxspins": "300", "maxhands": "300",
+		"history": "", "lossrebate": "0.1",
+	}
+	settings := s.Settings()
+	if len(settings) != len(want) {
+		t.Errorf("got %d settings %v, want %d", len(settings), settings, len(want))
+	}
+	for name, value := range want {
+		if got, ok := settings[name]; !ok || got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestScenarioUnknownField(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"typo.yaml": "game: roulette\nbankrol: 100\n",
+		"typo.json": `{"game": "baccarat", "win_gaol": 100}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadScenario(path); err == nil {
+			t.Errorf("%s: misspelt setting should fail", name)
+		}
+	}
+}
+
+func TestApplyScenarioVariant(t *testing.T) {
+	classic := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("roulette sim", flag.ContinueOnError)
+		fs.Bool("european", false, "")
+		fs.Bool("lapartage", false, "")
+		return fs
+	}
+	variants := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("analyze variants", flag.ContinueOnError)
+		fs.String("variant", "", "")
+		return fs
+	}
+
+	for _, test := range []struct {
+		fs      *flag.FlagSet
+		variant string
+		ok      bool
+	}{
+		{classic(), "la_partage", true},
+		{classic(), "american", true},
+		{classic(), "mini", false},
+		{classic(), "lightning", false},
+		{classic(), "europen", false},
+		{variants(), "mini", true},
+		{variants(), "europen", false},
+	} {
+		err := ApplyScenario(test.fs, &Scenario{Game: "roulette", Variant: test.variant})
+		if (err == nil) != test.ok {
+			t.Errorf("%s with variant %s: error %v, want ok %v", test.fs.Name(), test.variant, err, test.ok)
+		}
+	}
+
+	fs := classic()
+	if err := ApplyScenario(fs, &Scenario{Game: "roulette", Variant: "la_partage"}); err != nil {
+		t.Fatal(err)
+	}
+	if fs.Lookup("european").Value.String() != "true" || fs.Lookup("lapartage").Value.String() != "true" {
+		t.Errorf("la_partage set european %s, lapartage %s", fs.Lookup("european").Value, fs.Lookup("lapartage").Value)
+	}
+}
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// States of a live round
+const (
+	Open    = "open"    // Taking bets until the betting window closes
+	Closed  = "closed"  // No more bets
+	Dealing = "dealing" // The cards are revealed or the ball spins
+	Settled = "settled" // The bets are paid; the next round opens after a pause
+)
+
+// Timing is how long each part of a live round lasts, and how many rounds to run
+type Timing struct {
+	BettingSeconds float64 `json:"betting_seconds,omitempty"` // Betting window, 15 by default
+	RevealSeconds  float64 `json:"reveal_seconds,omitempty"`  // Between cards, and while the ball spins, 1 by default
+	PauseSeconds   float64 `json:"pause_seconds,omitempty"`   // After the bets are paid, 3 by default
+	Rounds         int     `json:"rounds,omitempty"`          // Rounds to run before the table stops, 0 for no end
+}
+
+// seconds converts a number of seconds to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Event is pushed to every client watching a table
+type Event struct {
+	Type     string     `json:"type"` // state, bet, card, draw, spin, result or error
+	Table    string     `json:"table"`
+	Round    int        `json:"round,omitempty"`
+	State    string     `json:"state,omitempty"`     // New state of the round, for state events
+	ClosesAt *time.Time `json:"closes_at,omitempty"` // When betting closes, for the open state
+	Bets     []Bet      `json:"bets,omitempty"`      // Bets just placed, for bet events
+	Hand     string     `json:"hand,omitempty"`      // Player or Banker, for card events
+	Card     string     `json:"card,omitempty"`      // Card just dealt, for card events
+	Value    *int       `json:"value,omitempty"`     // Value of the hand with the card, for card events
+	Draw     string     `json:"draw,omitempty"`      // Why a hand drew or stood, for draw events
+	Result   *Round     `json:"result,omitempty"`    // The settled round, for result events
+	Error    string     `json:"error,omitempty"`     // Why a client's message was refused, for error events
+}
+
+// live runs the rounds of a live table
+type live struct {
+	state    string
+	round    int // Number of the round being played
+	timing   Timing
+	stopping bool // End once the current round is settled
+}
+
+// client is a connection watching a table
+type client struct {
+	conn *websocket.Conn
+	send chan Event
+}
+
+// upgrader accepts WebSocket connections from pages served on the same origin
+var upgrader = websocket.Upgrader{}
+
+// broadcast pushes an event to every client watching the table, dropping
+// any client too slow to keep up. The caller holds the lock.
+func (s *Server) broadcast(t *table, event Event) {
+	event.Table = t.id
+	if event.Round == 0 && t.live != nil {
+		event.Round = t.live.round
+	}
+	for c := range t.clients {
+		select {
+		case c.send <- event:
+		default:
+			delete(t.clients, c)
+			close(c.send)
+		}
+	}
+}
+
+// setState moves a live round to its next state and tells the clients.
+// The caller holds the lock.
+func (s *Server) setState(t *table, state string) {
+	t.live.state = state
+	event := Event{Type: "state", State: state}
+	if state == Open {
+		closes := time.Now().Add(seconds(t.live.timing.BettingSeconds))
+		event.ClosesAt = &closes
+	}
+	s.broadcast(t, event)
+}
+
+// startLive makes a table deal its own rounds with the Timing in the body
+func (s *Server) startLive(id string, r *http.Request) (*Table, error) {
+	var timing Timing
+	if err := readJSON(r, &timing); err != nil {
+		return nil, err
+	}
+	if timing.BettingSeconds < 0 || timing.RevealSeconds < 0 || timing.PauseSeconds < 0 || timing.Rounds < 0 {
+		return nil, badRequest("timings and rounds cannot be negative")
+	}
+	if timing.BettingSeconds == 0 {
+		timing.BettingSeconds = 15
+	}
+	if timing.RevealSeconds == 0 {
+		timing.RevealSeconds = 1
+	}
+	if timing.PauseSeconds == 0 {
+		timing.PauseSeconds = 3
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return nil, notFound("no table %q", id)
+	}
+	if t.live != nil {
+		return nil, conflict("table %s is already live", id)
+	}
+	if len(t.bets) > 0 {
+		return nil, conflict("table %s has bets waiting for a round", id)
+	}
+	t.live = &live{timing: timing}
+	go s.run(t)
+	return t.view(), nil
+}
+
+// stopLive ends a live table once the round in play is settled
+func (s *Server) stopLive(id string) (*Table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return nil, notFound("no table %q", id)
+	}
+	if t.live == nil {
+		return nil, conflict("table %s is not live", id)
+	}
+	t.live.stopping = true
+	return t.view(), nil
+}
+
+// run plays the rounds of a live table: open for bets, closed, dealing
+// the cards or spinning, then settled, until it is stopped or has played
+// its rounds
+func (s *Server) run(t *table) {
+	timing := t.live.timing
+	for n := 1; ; n++ {
+		s.mu.Lock()
+		if t.live.stopping || (timing.Rounds > 0 && n > timing.Rounds) {
+			t.live = nil
+			s.broadcast(t, Event{Type: "state", Round: len(t.rounds)})
+			s.mu.Unlock()
+			return
+		}
+		t.live.round = len(t.rounds) + 1
+		s.setState(t, Open)
+		s.mu.Unlock()
+		time.Sleep(seconds(timing.BettingSeconds))
+
+		s.mu.Lock()
+		s.setState(t, Closed)
+		s.mu.Unlock()
+		time.Sleep(seconds(timing.RevealSeconds))
+
+		s.mu.Lock()
+		s.setState(t, Dealing)
+		round := t.play()
+		s.mu.Unlock()
+		s.reveal(t, round, seconds(timing.RevealSeconds))
+
+		s.mu.Lock()
+		s.pay(t, round)
+		s.broadcast(t, Event{Type: "result", Result: &round})
+		s.setState(t, Settled)
+		last := t.live.stopping || n == timing.Rounds
+		s.mu.Unlock()
+		if !last {
+			time.Sleep(seconds(timing.PauseSeconds))
+		}
+	}
+}
+
+// reveal pushes a round to the clients as the dealer plays it: baccarat
+// cards one by one in the order they leave the shoe, with each draw
+// decision, or the ball spinning before the result
+func (s *Server) reveal(t *table, round Round, pause time.Duration) {
+	emit := func(event Event) {
+		s.mu.Lock()
+		s.broadcast(t, event)
+		s.mu.Unlock()
+		time.Sleep(pause)
+	}
+	if round.Player == nil {
+		emit(Event{Type: "spin"})
+		return
+	}
+
+	var player, banker []string
+	deal := func(hand string, cards *[]string, card string) {
+		*cards = append(*cards, card)
+		value := cardsValue(*cards)
+		emit(Event{Type: "card", Hand: hand, Card: card, Value: &value})
+	}
+	deal("Player", &player, round.Player.Cards[0])
+	deal("Player", &player, round.Player.Cards[1])
+	deal("Banker", &banker, round.Banker.Cards[0])
+	deal("Banker", &banker, round.Banker.Cards[1])
+	emit(Event{Type: "draw", Draw: round.Draws[0]})
+	if len(round.Player.Cards) == 3 {
+		deal("Player", &player, round.Player.Cards[2])
+	}
+	if len(round.Draws) > 1 {
+		emit(Event{Type: "draw", Draw: round.Draws[1]})
+		if len(round.Banker.Cards) == 3 {
+			deal("Banker", &banker, round.Banker.Cards[2])
+		}
+	}
+}
+
+// cardsValue returns the baccarat value of cards shown like "A♥" or "10♠"
+func cardsValue(cards []string) int {
+	total := 0
+	for _, card := range cards {
+		rank := strings.TrimRight(card, "♥♦♣♠")
+		if rank == "A" {
+			total++
+		} else if value, err := strconv.Atoi(rank); err == nil && value < 10 {
+			total += value
+		}
+	}
+	return total % 10
+}
+
+// watch upgrades a request to a WebSocket that receives the table's
+// events and may place bets with {"type": "bet", "wallet": "w1", "bets": [...]}
+func (s *Server) watch(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	t, ok := s.tables[id]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, notFound("no table %q", id))
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // The upgrader has already replied
+	}
+
+	c := &client{conn: conn, send: make(chan Event, 64)}
+	go func() {
+		defer conn.Close()
+		for event := range c.send {
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Start the client off with where the table is
+	s.mu.Lock()
+	if t.clients == nil {
+		t.clients = map[*client]bool{}
+	}
+	t.clients[c] = true
+	hello := Event{Type: "state", Table: t.id, Round: len(t.rounds) + 1}
+	if t.live != nil {
+		hello.State, hello.Round = t.live.state, t.live.round
+	}
+	c.send <- hello
+	s.mu.Unlock()
+
+	for {
+		var message struct {
+			Type string `json:"type"`
+			betRequest
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			break
+		}
+
+		s.mu.Lock()
+		var event Event
+		if message.Type != "bet" {
+			event = Event{Type: "error", Table: t.id, Error: "unknown message type " + strconv.Quote(message.Type)}
+		} else if bets, err := s.bet(t, message.betRequest); err != nil {
+			event = Event{Type: "error", Table: t.id, Error: err.Error()}
+		} else {
+			s.broadcast(t, Event{Type: "bet", Bets: bets})
+		}
+		if event.Type != "" && t.clients[c] {
+			select {
+			case c.send <- event:
+			default:
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	if t.clients[c] {
+		delete(t.clients, c)
+		close(c.send)
+	}
+	s.mu.Unlock()
+}
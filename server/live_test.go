@@ -0,0 +1,155 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+	"github.com/gorilla/websocket"
+)
+
+// dial watches a table of the test server over a WebSocket
+func dial(t *testing.T, server *httptest.Server, table string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/tables/"+table+"/live", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+// readUntil reads events until one matches, returning every event read
+func readUntil(t *testing.T, conn *websocket.Conn, done func(Event) bool) []Event {
+	t.Helper()
+	var events []Event
+	for {
+		var event Event
+		if err := conn.ReadJSON(&event); err != nil {
+			t.Fatalf("after %+v: %v", events, err)
+		}
+		events = append(events, event)
+		if done(event) {
+			return events
+		}
+	}
+}
+
+// settled matches the event ending a live round
+func settled(event Event) bool {
+	return event.Type == "state" && event.State == Settled
+}
+
+func TestLiveBaccarat(t *testing.T) {
+	server := httptest.NewServer(New(7))
+	defer server.Close()
+	var wallet Wallet
+	call(t, server, "POST", "/wallets", `{"name": "Ann", "balance": 1000}`, &wallet)
+	call(t, server, "POST", "/tables", `{"game": "baccarat"}`, nil)
+
+	dealer, watcher := dial(t, server, "t1"), dial(t, server, "t1")
+	defer dealer.Close()
+	defer watcher.Close()
+	for _, conn := range []*websocket.Conn{dealer, watcher} {
+		if hello := readUntil(t, conn, func(Event) bool { return true }); hello[0].Type != "state" || hello[0].State != "" {
+			t.Fatalf("hello = %+v, want the state of a table that is not live", hello[0])
+		}
+	}
+
+	if status := call(t, server, "POST", "/tables/t1/live", `{"betting_seconds": 0.3, "reveal_seconds": 0.01, "rounds": 1}`, nil); status != http.StatusCreated {
+		t.Fatalf("start live = %d", status)
+	}
+	if status := call(t, server, "POST", "/tables/t1/rounds", "", nil); status != http.StatusConflict {
+		t.Errorf("dealing a live table by hand = %d, want 409", status)
+	}
+	readUntil(t, dealer, func(e Event) bool { return e.Type == "state" && e.State == Open })
+	dealer.WriteJSON(map[string]interface{}{"type": "bet", "wallet": wallet.ID, "bets": []Bet{{Bet: "Banker", Amount: casino.Dollars(100)}}})
+	dealer.WriteJSON(map[string]interface{}{"type": "bet", "wallet": wallet.ID, "bets": []Bet{{Bet: "Dragon", Amount: casino.Dollars(5)}}})
+
+	events := readUntil(t, watcher, settled)
+	var states []string
+	var cards, draws, bets int
+	var result *Round
+	for _, e := range events {
+		switch e.Type {
+		case "state":
+			states = append(states, e.State)
+		case "card":
+			cards++
+			if e.Value == nil || e.Card == "" || (e.Hand != "Player" && e.Hand != "Banker") {
+				t.Errorf("card event = %+v", e)
+			}
+		case "draw":
+			draws++
+		case "bet":
+			bets++
+		case "result":
+			result = e.Result
+		}
+	}
+	if got := strings.Join(states, " "); !strings.HasSuffix(got, "open closed dealing settled") {
+		t.Errorf("states = %s, want open closed dealing settled", got)
+	}
+	if result == nil || cards != len(result.Player.Cards)+len(result.Banker.Cards) || draws != len(result.Draws) {
+		t.Fatalf("%d cards and %d draws revealed for %+v", cards, draws, result)
+	}
+	if bets != 1 || len(result.Payouts) != 1 || result.Payouts[0].Bet.Bet != "Banker" {
+		t.Errorf("%d bets broadcast, payouts %+v, want the one Banker bet", bets, result.Payouts)
+	}
+	if refused := readUntil(t, dealer, func(e Event) bool { return e.Type == "error" }); !strings.Contains(refused[len(refused)-1].Error, "Dragon") {
+		t.Errorf("error = %q, want the Dragon bet refused", refused[len(refused)-1].Error)
+	}
+
+	// One round was asked for, so the table goes back to manual play
+	readUntil(t, watcher, func(e Event) bool { return e.Type == "state" && e.State == "" })
+	var table Table
+	call(t, server, "GET", "/tables/t1", "", &table)
+	call(t, server, "GET", "/wallets/"+wallet.ID, "", &wallet)
+	if table.State != "" || table.Rounds != 1 || wallet.Balance != casino.Dollars(900)+result.Payouts[0].Return {
+		t.Errorf("after the round: table %+v, balance %s", table, wallet.Balance)
+	}
+}
+
+func TestLiveRoulette(t *testing.T) {
+	server := httptest.NewServer(New(7))
+	defer server.Close()
+	var wallet Wallet
+	call(t, server, "POST", "/wallets", `{"name": "Bo", "balance": 100}`, &wallet)
+	call(t, server, "POST", "/tables", `{"game": "roulette", "european": true}`, nil)
+	watcher := dial(t, server, "t1")
+	defer watcher.Close()
+
+	call(t, server, "POST", "/tables/t1/live", `{"betting_seconds": 0.2, "reveal_seconds": 0.01, "pause_seconds": 0.5}`, nil)
+	readUntil(t, watcher, func(e Event) bool { return e.Type == "state" && e.State == Open })
+	bets := `{"wallet": "` + wallet.ID + `", "bets": [{"bet": "red", "amount": 10}]}`
+	if status := call(t, server, "POST", "/tables/t1/bets", bets, nil); status != http.StatusCreated {
+		t.Fatalf("bet over HTTP while open = %d", status)
+	}
+	events := readUntil(t, watcher, settled)
+	if status := call(t, server, "POST", "/tables/t1/bets", bets, nil); status != http.StatusConflict {
+		t.Errorf("bet once the round is settled = %d, want 409", status)
+	}
+	spun := false
+	var result *Round
+	for _, e := range events {
+		spun = spun || e.Type == "spin"
+		if e.Type == "result" {
+			result = e.Result
+		}
+	}
+	if !spun || result == nil || result.Pocket == "" || len(result.Payouts) != 1 {
+		t.Fatalf("events %+v", events)
+	}
+
+	// Stopping during the pause ends the table before the next round opens
+	if status := call(t, server, "DELETE", "/tables/t1/live", "", nil); status != http.StatusOK {
+		t.Fatalf("stop = %d", status)
+	}
+	readUntil(t, watcher, func(e Event) bool { return e.Type == "state" && e.State == "" })
+	if status := call(t, server, "DELETE", "/tables/t1/live", "", nil); status != http.StatusConflict {
+		t.Errorf("stopping a table that is not live = %d, want 409", status)
+	}
+}
@@ -0,0 +1,217 @@
+// Package server runs baccarat and roulette tables behind an HTTP/JSON
+// API. The shoes, wheels, wallets and round history live on the server;
+// clients only open tables, place bets and ask for the next round.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// Server holds every table and wallet and serves the API
+type Server struct {
+	mu      sync.Mutex
+	seed    int64
+	tables  map[string]*table
+	wallets map[string]*Wallet
+	opened  int // Tables opened so far, numbering the next one
+	created int // Wallets created so far, numbering the next one
+}
+
+// New returns an empty server. Table i shuffles and spins from a seed
+// derived from seed, so a server started with the same seed deals the
+// same rounds; 0 picks a seed from the clock.
+func New(seed int64) *Server {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Server{seed: seed, tables: map[string]*table{}, wallets: map[string]*Wallet{}}
+}
+
+// Wallet is a player's bankroll, shared by every table they bet at
+type Wallet struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Balance casino.Money `json:"balance"`
+}
+
+// apiError is an error with the HTTP status it is reported with
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string { return e.message }
+
+// badRequest reports an invalid request
+func badRequest(format string, args ...interface{}) error {
+	return &apiError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+// notFound reports a table or wallet that does not exist
+func notFound(format string, args ...interface{}) error {
+	return &apiError{http.StatusNotFound, fmt.Sprintf(format, args...)}
+}
+
+// conflict reports a request the table cannot take in its current state
+func conflict(format string, args ...interface{}) error {
+	return &apiError{http.StatusConflict, fmt.Sprintf(format, args...)}
+}
+
+// writeJSON writes a response body
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error as {"error": "..."}
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var e *apiError
+	if errors.As(err, &e) {
+		status = e.status
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// readJSON decodes a request body, refusing fields the API does not know.
+// An empty body leaves every field at its default.
+func readJSON(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil && err != io.EOF {
+		return badRequest("invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+// ServeHTTP routes the API:
+//
+//	POST /wallets                 create a wallet
+//	GET  /wallets/{id}            fetch a wallet
+//	POST /tables                  open a table
+//	GET  /tables/{id}             fetch a table and its open bets
+//	POST /tables/{id}/bets        place bets for the next round
+//	POST /tables/{id}/rounds      deal or spin the next round and settle it
+//	GET  /tables/{id}/rounds      fetch the round history, ?limit=n for the last n
+//	POST /tables/{id}/live        deal rounds on a timer, see Timing
+//	DELETE /tables/{id}/live      stop dealing rounds once the one in play is settled
+//	GET  /tables/{id}/live        watch the table and bet over a WebSocket
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	route := func(method string, n int, collection string) bool {
+		return r.Method == method && len(parts) == n && parts[0] == collection
+	}
+	if route(http.MethodGet, 3, "tables") && parts[2] == "live" {
+		s.watch(w, r, parts[1])
+		return
+	}
+
+	var status int
+	var body interface{}
+	var err error
+	switch {
+	case route(http.MethodPost, 1, "wallets"):
+		status = http.StatusCreated
+		body, err = s.createWallet(r)
+	case route(http.MethodGet, 2, "wallets"):
+		status = http.StatusOK
+		body, err = s.wallet(parts[1])
+	case route(http.MethodPost, 1, "tables"):
+		status = http.StatusCreated
+		body, err = s.openTable(r)
+	case route(http.MethodGet, 2, "tables"):
+		status = http.StatusOK
+		body, err = s.table(parts[1])
+	case route(http.MethodPost, 3, "tables") && parts[2] == "bets":
+		status = http.StatusCreated
+		body, err = s.placeBets(parts[1], r)
+	case route(http.MethodPost, 3, "tables") && parts[2] == "rounds":
+		status = http.StatusCreated
+		body, err = s.playRound(parts[1])
+	case route(http.MethodGet, 3, "tables") && parts[2] == "rounds":
+		status = http.StatusOK
+		body, err = s.history(parts[1], r)
+	case route(http.MethodPost, 3, "tables") && parts[2] == "live":
+		status = http.StatusCreated
+		body, err = s.startLive(parts[1], r)
+	case route(http.MethodDelete, 3, "tables") && parts[2] == "live":
+		status = http.StatusOK
+		body, err = s.stopLive(parts[1])
+	default:
+		err = &apiError{http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path)}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, body)
+}
+
+// createWallet creates a wallet from {"name": "...", "balance": 1000}
+func (s *Server) createWallet(r *http.Request) (*Wallet, error) {
+	var request struct {
+		Name    string       `json:"name"`
+		Balance casino.Money `json:"balance"`
+	}
+	if err := readJSON(r, &request); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(request.Name) == "" {
+		return nil, badRequest("a wallet needs a name")
+	}
+	if request.Balance <= 0 {
+		return nil, badRequest("a wallet needs a positive balance")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.created++
+	wallet := &Wallet{ID: "w" + strconv.Itoa(s.created), Name: request.Name, Balance: request.Balance}
+	s.wallets[wallet.ID] = wallet
+	snapshot := *wallet
+	return &snapshot, nil
+}
+
+// wallet returns a copy of a wallet
+func (s *Server) wallet(id string) (*Wallet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wallet, ok := s.wallets[id]
+	if !ok {
+		return nil, notFound("no wallet %q", id)
+	}
+	snapshot := *wallet
+	return &snapshot, nil
+}
+
+// history returns the rounds of a table, the last ?limit=n when given
+func (s *Server) history(id string, r *http.Request) ([]Round, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return nil, notFound("no table %q", id)
+	}
+	rounds := t.rounds
+	if text := r.URL.Query().Get("limit"); text != "" {
+		limit, err := strconv.Atoi(text)
+		if err != nil || limit < 0 {
+			return nil, badRequest("invalid limit %q", text)
+		}
+		if limit < len(rounds) {
+			rounds = rounds[len(rounds)-limit:]
+		}
+	}
+	return append([]Round{}, rounds...), nil
+}
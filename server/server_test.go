@@ -0,0 +1,194 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	casino "github.com/BryceWayne/casino"
+)
+
+// call sends a request to the test server and decodes the response into out
+func call(t *testing.T, server *httptest.Server, method, path, body string, out interface{}) int {
+	t.Helper()
+	request, err := http.NewRequest(method, server.URL+path, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+	return response.StatusCode
+}
+
+func TestBaccaratTable(t *testing.T) {
+	server := httptest.NewServer(New(7))
+	defer server.Close()
+
+	var wallet Wallet
+	if status := call(t, server, "POST", "/wallets", `{"name": "Ann", "balance": 1000}`, &wallet); status != http.StatusCreated || wallet.ID == "" {
+		t.Fatalf("create wallet = %d %+v", status, wallet)
+	}
+	var table Table
+	if status := call(t, server, "POST", "/tables", `{"game": "baccarat", "decks": 6, "max_bet": 500}`, &table); status != http.StatusCreated {
+		t.Fatalf("open table = %d", status)
+	}
+	if table.Config.Decks != 6 || table.Config.CommissionRate == nil || *table.Config.CommissionRate != 0.05 || table.CardsLeft != 6*52 {
+		t.Errorf("table = %+v, want 6 decks and a 5%% commission", table)
+	}
+
+	bets := `{"wallet": "` + wallet.ID + `", "bets": [{"bet": "banker", "amount": 100}, {"bet": "Player Pair", "amount": 10}]}`
+	if status := call(t, server, "POST", "/tables/"+table.ID+"/bets", bets, &table); status != http.StatusCreated || len(table.Bets) != 2 || table.Bets[0].Bet != "Banker" {
+		t.Fatalf("place bets = %d %+v", status, table.Bets)
+	}
+	call(t, server, "GET", "/wallets/"+wallet.ID, "", &wallet)
+	if wallet.Balance != casino.Dollars(890) {
+		t.Errorf("balance after betting = %s, want $890", wallet.Balance)
+	}
+
+	var round Round
+	if status := call(t, server, "POST", "/tables/"+table.ID+"/rounds", "", &round); status != http.StatusCreated {
+		t.Fatalf("deal = %d", status)
+	}
+	if round.Number != 1 || round.Player == nil || round.Banker == nil || round.Winner == "" || len(round.Payouts) != 2 {
+		t.Fatalf("round = %+v", round)
+	}
+	paid := casino.Money(0)
+	for _, payout := range round.Payouts {
+		paid += payout.Return
+	}
+	call(t, server, "GET", "/wallets/"+wallet.ID, "", &wallet)
+	if wallet.Balance != casino.Dollars(890)+paid {
+		t.Errorf("balance after the round = %s, want $890 plus %s", wallet.Balance, paid)
+	}
+
+	call(t, server, "POST", "/tables/"+table.ID+"/rounds", "", nil)
+	var history []Round
+	if status := call(t, server, "GET", "/tables/"+table.ID+"/rounds?limit=1", "", &history); status != http.StatusOK || len(history) != 1 || history[0].Number != 2 {
+		t.Errorf("history = %d %+v, want round 2 alone", status, history)
+	}
+}
+
+func TestNoCommissionTable(t *testing.T) {
+	server := httptest.NewServer(New(7))
+	defer server.Close()
+
+	var wallet Wallet
+	call(t, server, "POST", "/wallets", `{"name": "Cy", "balance": 10000}`, &wallet)
+	var table Table
+	if status := call(t, server, "POST", "/tables", `{"game": "baccarat", "commission_rate": 0}`, &table); status != http.StatusCreated {
+		t.Fatalf("open table = %d", status)
+	}
+	if table.Config.CommissionRate == nil || *table.Config.CommissionRate != 0 {
+		t.Fatalf("table = %+v, want no commission", table)
+	}
+
+	// A Banker win pays even money in full
+	bets := `{"wallet": "` + wallet.ID + `", "bets": [{"bet": "banker", "amount": 100}]}`
+	for i := 0; i < 50; i++ {
+		call(t, server, "POST", "/tables/"+table.ID+"/bets", bets, nil)
+		var round Round
+		call(t, server, "POST", "/tables/"+table.ID+"/rounds", "", &round)
+		if round.Winner == "Banker" {
+			if net := round.Payouts[0].Net; net != casino.Dollars(100) {
+				t.Errorf("Banker win paid %s, want $100", net)
+			}
+			return
+		}
+	}
+	t.Fatal("Banker did not win in 50 rounds")
+}
+
+func TestRouletteTable(t *testing.T) {
+	server := httptest.NewServer(New(7))
+	defer server.Close()
+
+	var wallet Wallet
+	call(t, server, "POST", "/wallets", `{"name": "Bo", "balance": 100}`, &wallet)
+	var table Table
+	call(t, server, "POST", "/tables", `{"game": "roulette", "european": true, "max_bet": 50}`, &table)
+
+	bets := `{"wallet": "` + wallet.ID + `", "bets": [{"bet": "red", "amount": 25}, {"bet": "split 17-20", "amount": 5}]}`
+	if status := call(t, server, "POST", "/tables/"+table.ID+"/bets", bets, &table); status != http.StatusCreated || len(table.Bets) != 2 {
+		t.Fatalf("place bets = %d %+v", status, table.Bets)
+	}
+	var round Round
+	call(t, server, "POST", "/tables/"+table.ID+"/rounds", "", &round)
+	if round.Pocket == "" || round.Color == "" || len(round.Payouts) != 2 {
+		t.Fatalf("round = %+v", round)
+	}
+
+	// The same seed spins the same numbers
+	other := httptest.NewServer(New(7))
+	defer other.Close()
+	var again Round
+	call(t, other, "POST", "/tables", `{"game": "roulette", "european": true}`, nil)
+	call(t, other, "POST", "/tables/"+table.ID+"/rounds", "", &again)
+	if again.Pocket != round.Pocket {
+		t.Errorf("seed 7 spun %s then %s", round.Pocket, again.Pocket)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	server := httptest.NewServer(New(7))
+	defer server.Close()
+	call(t, server, "POST", "/wallets", `{"name": "Cy", "balance": 500}`, nil)
+	call(t, server, "POST", "/tables", `{"game": "roulette", "max_bet": 100, "max_total": 120}`, nil)
+	call(t, server, "POST", "/tables", `{"game": "baccarat", "min_bet": 10}`, nil)
+
+	// Enough bets of the largest amount to wrap an int64 total past zero
+	const maxBet = `{"bet": "banker", "amount": 1000000000000}`
+	wrapping := `{"wallet": "w1", "bets": [` + strings.Repeat(maxBet+", ", 99_999) + maxBet + `]}`
+
+	cases := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", "/wallets", `{"name": "Dee"}`, http.StatusBadRequest},
+		{"POST", "/wallets", `{"name": "Dee", "balance": 10, "vip": true}`, http.StatusBadRequest},
+		{"GET", "/wallets/w9", "", http.StatusNotFound},
+		{"POST", "/tables", `{"game": "poker"}`, http.StatusBadRequest},
+		{"POST", "/tables", `{"game": "roulette", "la_partage": true}`, http.StatusBadRequest},
+		{"POST", "/tables", `{"game": "baccarat", "commission": "owed"}`, http.StatusBadRequest},
+		{"POST", "/tables", `{"game": "baccarat", "commission_rate": -0.05}`, http.StatusBadRequest},
+		{"POST", "/tables", `{"game": "roulette", "commission_rate": 0}`, http.StatusBadRequest},
+		{"GET", "/tables/t9", "", http.StatusNotFound},
+		{"POST", "/tables/t9/rounds", "", http.StatusNotFound},
+		{"DELETE", "/tables/t1", "", http.StatusNotFound},
+		{"POST", "/tables/t1/bets", `{"wallet": "w9", "bets": [{"bet": "red", "amount": 5}]}`, http.StatusNotFound},
+		{"POST", "/tables/t1/bets", `{"wallet": "w1", "bets": [{"bet": "street 1-2-4", "amount": 5}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t1/bets", `{"wallet": "w1", "bets": [{"bet": "straight 37", "amount": 5}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t1/bets", `{"wallet": "w1", "bets": [{"bet": "red", "amount": 0}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t1/bets", `{"wallet": "w1", "bets": [{"bet": "red", "amount": 150}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t1/bets", `{"wallet": "w1", "bets": [{"bet": "red", "amount": 90}, {"bet": "black", "amount": 40}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t2/bets", `{"wallet": "w1", "bets": [{"bet": "dragon", "amount": 20}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t2/bets", `{"wallet": "w1", "bets": [{"bet": "tie", "amount": 5}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t2/bets", `{"wallet": "w1", "bets": [{"bet": "banker", "amount": 400}, {"bet": "tie", "amount": 200}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t2/bets", `{"wallet": "w1", "bets": [{"bet": "banker", "amount": 92233720368547759}]}`, http.StatusBadRequest},
+		{"POST", "/tables/t2/bets", wrapping, http.StatusBadRequest},
+		{"GET", "/tables/t1/rounds?limit=x", "", http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		var body map[string]string
+		if status := call(t, server, c.method, c.path, c.body, &body); status != c.want || body["error"] == "" {
+			t.Errorf("%s %s %s = %d %v, want %d with an error", c.method, c.path, c.body, status, body, c.want)
+		}
+	}
+
+	// Nothing was taken from the wallet by the refused bets
+	var wallet Wallet
+	call(t, server, "GET", "/wallets/w1", "", &wallet)
+	if wallet.Balance != casino.Dollars(500) {
+		t.Errorf("balance = %s, want $500", wallet.Balance)
+	}
+}
@@ -0,0 +1,431 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	casino "github.com/BryceWayne/casino"
+	baccarat "github.com/BryceWayne/casino/Baccarat"
+	roulette "github.com/BryceWayne/casino/Roulette"
+)
+
+// Games a table can run
+const (
+	Baccarat = "baccarat"
+	Roulette = "roulette"
+)
+
+// TableConfig is the game, rules and limits a table is opened with
+type TableConfig struct {
+	Game           string       `json:"game"`                      // baccarat or roulette
+	Decks          int          `json:"decks,omitempty"`           // Decks in the baccarat shoe, 8 by default
+	Commission     string       `json:"commission,omitempty"`      // How Banker commission is paid, exact or round
+	CommissionRate *float64     `json:"commission_rate,omitempty"` // Share of a Banker win kept by the house, 0.05 when unset
+	Chip           casino.Money `json:"chip,omitempty"`            // Smallest chip, used to round Banker wins
+	European       bool         `json:"european,omitempty"`        // Single zero roulette wheel
+	LaPartage      bool         `json:"la_partage,omitempty"`      // Return half of a losing even-money bet on zero
+	MinBet         casino.Money `json:"min_bet,omitempty"`         // Smallest single bet
+	MaxBet         casino.Money `json:"max_bet,omitempty"`         // Largest single bet, 0 for no limit
+	MaxTotal       casino.Money `json:"max_total,omitempty"`       // Largest total a wallet stakes on a round, 0 for no limit
+}
+
+// Bet is a wager on the next round of a table
+type Bet struct {
+	Wallet string       `json:"wallet"`
+	Bet    string       `json:"bet"` // Player, Banker, Tie, Player Pair or Banker Pair, or a roulette bet such as "split 17-20"
+	Amount casino.Money `json:"amount"`
+}
+
+// Payout is how a bet was settled
+type Payout struct {
+	Bet
+	Outcome string       `json:"outcome"`
+	Net     casino.Money `json:"net"`    // Profit, negative for a loss
+	Return  casino.Money `json:"return"` // Paid back into the wallet, stake included
+}
+
+// Hand is a baccarat hand as the API shows it
+type Hand struct {
+	Cards []string `json:"cards"`
+	Value int      `json:"value"`
+}
+
+// Round is one deal or spin of a table and how its bets were settled
+type Round struct {
+	Number  int      `json:"number"`
+	NewShoe bool     `json:"new_shoe,omitempty"`
+	Player  *Hand    `json:"player,omitempty"`
+	Banker  *Hand    `json:"banker,omitempty"`
+	Draws   []string `json:"draws,omitempty"`
+	Winner  string   `json:"winner,omitempty"`
+	Pocket  string   `json:"pocket,omitempty"`
+	Color   string   `json:"color,omitempty"`
+	Payouts []Payout `json:"payouts"`
+}
+
+// Table is a table as the API shows it
+type Table struct {
+	ID        string      `json:"id"`
+	Config    TableConfig `json:"config"`
+	Rounds    int         `json:"rounds"`
+	CardsLeft int         `json:"cards_left,omitempty"`
+	State     string      `json:"state,omitempty"` // State of the round while the table is live
+	Bets      []Bet       `json:"bets"`            // Bets placed on the next round
+}
+
+// table is a running table: its shoe or wheel, the bets on the next
+// round and every round played
+type table struct {
+	id     string
+	config TableConfig
+
+	// Baccarat
+	deck       *baccarat.Deck
+	commission casino.Commission
+
+	// Roulette
+	variant roulette.Classic
+	source  roulette.SpinSource
+	limits  roulette.TableLimits
+
+	bets    []Bet
+	rounds  []Round
+	live    *live // Runs the rounds while the table is live, nil otherwise
+	clients map[*client]bool
+}
+
+// view returns the table as the API shows it
+func (t *table) view() *Table {
+	view := &Table{ID: t.id, Config: t.config, Rounds: len(t.rounds), Bets: append([]Bet{}, t.bets...)}
+	if t.deck != nil {
+		view.CardsLeft = len(t.deck.Cards)
+	}
+	if t.live != nil {
+		view.State = t.live.state
+	}
+	return view
+}
+
+// newTable checks a configuration, fills in its defaults and builds the
+// shoe or wheel, shuffling and spinning from the seed
+func newTable(id string, config TableConfig, seed int64) (*table, error) {
+	if config.MinBet < 0 || config.MaxBet < 0 || config.MaxTotal < 0 {
+		return nil, badRequest("limits cannot be negative")
+	}
+	if config.MaxBet > 0 && config.MinBet > config.MaxBet {
+		return nil, badRequest("minimum bet %s is over the maximum %s", config.MinBet, config.MaxBet)
+	}
+	t := &table{id: id, config: config}
+
+	switch config.Game {
+	case Baccarat:
+		if config.European || config.LaPartage {
+			return nil, badRequest("european and la_partage are roulette rules")
+		}
+		if t.config.Decks == 0 {
+			t.config.Decks = 8
+		}
+		if t.config.Decks < 1 || t.config.Decks > 16 {
+			return nil, badRequest("a shoe holds 1 to 16 decks, not %d", t.config.Decks)
+		}
+		if t.config.Commission == "" {
+			t.config.Commission = string(casino.CommissionExact)
+		}
+		rule, err := casino.ParseCommissionRule(t.config.Commission)
+		if err != nil {
+			return nil, badRequest("%v", err)
+		}
+		if rule == casino.CommissionOwed {
+			return nil, badRequest("owed commission is not offered by the API, use exact or round")
+		}
+		rate := 0.05
+		if config.CommissionRate != nil {
+			rate = *config.CommissionRate
+		}
+		if rate < 0 || rate >= 1 {
+			return nil, badRequest("commission rate %v is not between 0 and 1", rate)
+		}
+		t.config.CommissionRate = &rate
+		t.commission = casino.Commission{Rate: rate, Rule: rule, Unit: t.config.Chip}
+		t.deck = &baccarat.Deck{Rand: casino.NewRand(seed)}
+		t.newShoe()
+
+	case Roulette:
+		if config.Decks != 0 || config.Commission != "" || config.CommissionRate != nil || config.Chip != 0 {
+			return nil, badRequest("decks, commission, commission_rate and chip are baccarat rules")
+		}
+		if config.LaPartage && !config.European {
+			return nil, badRequest("la partage needs a European wheel")
+		}
+		wheel, err := roulette.NewWheel(config.European, nil)
+		if err != nil {
+			return nil, badRequest("%v", err)
+		}
+		t.variant = roulette.Classic{European: config.European, LaPartage: config.LaPartage}
+		t.source = roulette.Seeded(wheel, seed)
+		limit := roulette.Limit{Min: config.MinBet, Max: config.MaxBet}
+		t.limits = roulette.TableLimits{Inside: limit, Outside: limit, Total: roulette.Limit{Max: config.MaxTotal}}
+
+	default:
+		return nil, badRequest("unknown game %q, want %s or %s", config.Game, Baccarat, Roulette)
+	}
+	return t, nil
+}
+
+// newShoe fills and shuffles the shoe, keeping its generator
+func (t *table) newShoe() {
+	rng := t.deck.Rand
+	*t.deck = *baccarat.NewShoe(t.config.Decks)
+	t.deck.Rand = rng
+	t.deck.Shuffle()
+}
+
+// baccaratBets maps the names a client may send to the bet they place
+var baccaratBets = map[string]string{
+	"player": "Player", "banker": "Banker", "tie": "Tie",
+	"player pair": baccarat.PlayerPair, "banker pair": baccarat.BankerPair,
+}
+
+// check validates bets a wallet adds to the round, against the rules of
+// the game, the table limits and the bets it already has down
+func (t *table) check(bets []Bet) ([]Bet, error) {
+	if len(bets) == 0 {
+		return nil, badRequest("no bets")
+	}
+	checked := make([]Bet, len(bets))
+	for i, bet := range bets {
+		if bet.Amount <= 0 {
+			return nil, badRequest("bet %q needs a positive amount", bet.Bet)
+		}
+		if t.config.Game == Baccarat {
+			name, ok := baccaratBets[strings.Join(strings.Fields(strings.ToLower(bet.Bet)), " ")]
+			if !ok {
+				return nil, badRequest("unknown baccarat bet %q, want Player, Banker, Tie, Player Pair or Banker Pair", bet.Bet)
+			}
+			bet.Bet = name
+			if bet.Amount < t.config.MinBet || (t.config.MaxBet > 0 && bet.Amount > t.config.MaxBet) {
+				return nil, badRequest("%s %s is outside the table limits", bet.Bet, bet.Amount)
+			}
+		} else {
+			parsed, err := roulette.ParseBet(bet.Bet, t.variant)
+			if err != nil {
+				return nil, badRequest("%v", err)
+			}
+			bet.Bet = parsed.String()
+		}
+		checked[i] = bet
+	}
+
+	// Limits on the total, and roulette's, count the bets already down
+	wallet := bets[0].Wallet
+	var total casino.Money
+	var layout []roulette.Bet
+	for _, bet := range append(t.walletBets(wallet), checked...) {
+		var ok bool
+		if total, ok = total.Add(bet.Amount); !ok {
+			return nil, badRequest("the bets add up to more than can be staked")
+		}
+		if t.config.Game == Roulette {
+			parsed, _ := roulette.ParseBet(bet.Bet, t.variant)
+			parsed.Amount = bet.Amount
+			layout = append(layout, parsed)
+		}
+	}
+	if t.config.Game == Roulette {
+		if err := t.limits.Check(layout); err != nil {
+			return nil, badRequest("%v", err)
+		}
+	} else if t.config.MaxTotal > 0 && total > t.config.MaxTotal {
+		return nil, badRequest("a total of %s is over the table limit of %s", total, t.config.MaxTotal)
+	}
+	return checked, nil
+}
+
+// walletBets returns the bets a wallet has on the next round
+func (t *table) walletBets(wallet string) []Bet {
+	var bets []Bet
+	for _, bet := range t.bets {
+		if bet.Wallet == wallet {
+			bets = append(bets, bet)
+		}
+	}
+	return bets
+}
+
+// play deals or spins the next round and settles its bets, returning what
+// each pays back to its wallet
+func (t *table) play() Round {
+	round := Round{Number: len(t.rounds) + 1, Payouts: []Payout{}}
+	settle := func(bet Bet, settlement casino.Settlement) {
+		round.Payouts = append(round.Payouts, Payout{Bet: bet, Outcome: settlement.Outcome.String(), Net: settlement.Net, Return: settlement.Return})
+	}
+
+	if t.config.Game == Baccarat {
+		// Start a new shoe when it's close to being empty, like the simulator
+		if len(t.deck.Cards) < 6 {
+			t.newShoe()
+			round.NewShoe = true
+		}
+		playerHand, bankerHand := baccarat.DealInitialHands(t.deck)
+		draws := baccarat.PlayThirdCards(t.deck, &playerHand, &bankerHand)
+		round.Player, round.Banker = showHand(playerHand), showHand(bankerHand)
+		round.Draws = []string{draws.Player}
+		if !draws.Natural {
+			round.Draws = append(round.Draws, draws.Banker)
+		}
+		round.Winner = baccarat.DetermineWinner(playerHand, bankerHand)
+		for _, bet := range t.bets {
+			if bet.Bet == baccarat.PlayerPair || bet.Bet == baccarat.BankerPair {
+				settle(bet, baccarat.SettleSideBet(bet.Bet, bet.Amount, playerHand, bankerHand))
+			} else {
+				settle(bet, baccarat.SettleBet(bet.Bet, bet.Amount, round.Winner, &t.commission))
+			}
+		}
+	} else {
+		spin := t.variant.Spin(t.source)
+		pocket := spin.Balls[0]
+		round.Pocket, round.Color = roulette.PocketName(pocket), roulette.Color(pocket)
+		for _, bet := range t.bets {
+			parsed, _ := roulette.ParseBet(bet.Bet, t.variant)
+			parsed.Amount = bet.Amount
+			settle(bet, t.variant.Settle(parsed, spin))
+		}
+	}
+
+	t.bets = nil
+	return round
+}
+
+// cardSuits are the symbols a card's suit is shown with
+var cardSuits = map[string]string{"Hearts": "♥", "Diamonds": "♦", "Clubs": "♣", "Spades": "♠"}
+
+// showHand returns a hand as the API shows it, such as ["A♥", "10♠"]
+func showHand(hand baccarat.Hand) *Hand {
+	shown := &Hand{Value: hand.Value()}
+	for _, card := range hand.Cards {
+		shown.Cards = append(shown.Cards, card.Rank+cardSuits[card.Suit])
+	}
+	return shown
+}
+
+// openTable opens a table from a TableConfig
+func (s *Server) openTable(r *http.Request) (*Table, error) {
+	var config TableConfig
+	if err := readJSON(r, &config); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := "t" + strconv.Itoa(s.opened+1)
+	t, err := newTable(id, config, casino.SessionSeed(s.seed, s.opened))
+	if err != nil {
+		return nil, err
+	}
+	s.opened++
+	s.tables[id] = t
+	return t.view(), nil
+}
+
+// table returns a table and its open bets
+func (s *Server) table(id string) (*Table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return nil, notFound("no table %q", id)
+	}
+	return t.view(), nil
+}
+
+// betRequest is {"wallet": "w1", "bets": [{"bet": "Banker", "amount": 100}]}
+type betRequest struct {
+	Wallet string `json:"wallet"`
+	Bets   []struct {
+		Bet    string       `json:"bet"`
+		Amount casino.Money `json:"amount"`
+	} `json:"bets"`
+}
+
+// placeBets places bets on the next round of a table
+func (s *Server) placeBets(id string, r *http.Request) (*Table, error) {
+	var request betRequest
+	if err := readJSON(r, &request); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return nil, notFound("no table %q", id)
+	}
+	bets, err := s.bet(t, request)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcast(t, Event{Type: "bet", Bets: bets})
+	return t.view(), nil
+}
+
+// bet places a wallet's bets on the next round, taking the stakes from
+// the wallet. Either every bet is placed or, when one is invalid or the
+// wallet cannot cover them, none. The caller holds the lock.
+func (s *Server) bet(t *table, request betRequest) ([]Bet, error) {
+	if t.live != nil && t.live.state != Open {
+		return nil, conflict("betting is %s on table %s", t.live.state, t.id)
+	}
+	wallet, ok := s.wallets[request.Wallet]
+	if !ok {
+		return nil, notFound("no wallet %q", request.Wallet)
+	}
+	bets := make([]Bet, len(request.Bets))
+	for i, bet := range request.Bets {
+		bets[i] = Bet{Wallet: wallet.ID, Bet: bet.Bet, Amount: bet.Amount}
+	}
+	bets, err := t.check(bets)
+	if err != nil {
+		return nil, err
+	}
+	var total casino.Money
+	for _, bet := range bets {
+		var ok bool
+		if total, ok = total.Add(bet.Amount); !ok {
+			return nil, badRequest("the bets add up to more than can be staked")
+		}
+	}
+	if total > wallet.Balance {
+		return nil, badRequest("bets of %s are more than the %s in wallet %s", total, wallet.Balance, wallet.ID)
+	}
+	wallet.Balance -= total
+	t.bets = append(t.bets, bets...)
+	return bets, nil
+}
+
+// playRound deals or spins the next round of a table and pays its bets
+// back into their wallets
+func (s *Server) playRound(id string) (*Round, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return nil, notFound("no table %q", id)
+	}
+	if t.live != nil {
+		return nil, conflict("table %s is live and deals its own rounds", id)
+	}
+	round := t.play()
+	s.pay(t, round)
+	s.broadcast(t, Event{Type: "result", Round: round.Number, Result: &round})
+	return &round, nil
+}
+
+// pay credits each payout of a round to its wallet and records the round.
+// The caller holds the lock.
+func (s *Server) pay(t *table, round Round) {
+	for _, payout := range round.Payouts {
+		s.wallets[payout.Wallet].Balance += payout.Return
+	}
+	t.rounds = append(t.rounds, round)
+}
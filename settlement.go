@@ -0,0 +1,76 @@
+// Package casino holds the types shared by every game in the module.
+package casino
+
+// Outcome is how a bet was settled
+type Outcome int
+
+const (
+	Lose     Outcome = iota
+	Win              // The bet won and was paid
+	Push             // The stake was returned
+	HalfLose         // Half the stake was returned, as with la partage
+)
+
+// String returns the name of the outcome
+func (o Outcome) String() string {
+	switch o {
+	case Win:
+		return "Win"
+	case Push:
+		return "Push"
+	case HalfLose:
+		return "HalfLose"
+	}
+	return "Lose"
+}
+
+// Settlement is the result of settling a single bet
+type Settlement struct {
+	Outcome Outcome
+	Stake   Money // Amount wagered
+	Net     Money // Profit, negative for a loss
+	Return  Money // Amount handed back to the player, stake included
+}
+
+// Won settles a winning bet paying net on top of the stake
+func Won(stake, net Money) Settlement {
+	return Settlement{Outcome: Win, Stake: stake, Net: net, Return: stake + net}
+}
+
+// Lost settles a losing bet
+func Lost(stake Money) Settlement {
+	return Settlement{Outcome: Lose, Stake: stake, Net: -stake}
+}
+
+// Pushed settles a bet that neither wins nor loses
+func Pushed(stake Money) Settlement {
+	return Settlement{Outcome: Push, Stake: stake, Return: stake}
+}
+
+// HalfLost settles a bet that loses half its stake
+func HalfLost(stake Money) Settlement {
+	kept := stake / 2
+	return Settlement{Outcome: HalfLose, Stake: stake, Net: kept - stake, Return: kept}
+}
+
+// Combine adds up settlements of the same wager, such as one bet standing
+// on several balls, into a single settlement
+func Combine(settlements ...Settlement) Settlement {
+	var total Settlement
+	for _, s := range settlements {
+		total.Stake += s.Stake
+		total.Net += s.Net
+		total.Return += s.Return
+	}
+	switch {
+	case total.Net > 0:
+		total.Outcome = Win
+	case total.Net == 0:
+		total.Outcome = Push
+	case total.Return > 0:
+		total.Outcome = HalfLose
+	default:
+		total.Outcome = Lose
+	}
+	return total
+}
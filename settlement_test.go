@@ -0,0 +1,25 @@
+package casino
+
+import "testing"
+
+func TestSettlements(t *testing.T) {
+	cases := []struct {
+		name string
+		got  Settlement
+		want Settlement
+	}{
+		{"won", Won(10, 20), Settlement{Win, 10, 20, 30}},
+		{"lost", Lost(10), Settlement{Lose, 10, -10, 0}},
+		{"pushed", Pushed(10), Settlement{Push, 10, 0, 10}},
+		{"half lost", HalfLost(10), Settlement{HalfLose, 10, -5, 5}},
+		{"combined win", Combine(Won(10, 20), Lost(10)), Settlement{Win, 20, 10, 30}},
+		{"combined push", Combine(Won(10, 10), Lost(10)), Settlement{Push, 20, 0, 20}},
+		{"combined half loss", Combine(HalfLost(10), Lost(10)), Settlement{HalfLose, 20, -15, 5}},
+		{"combined loss", Combine(Lost(10), Lost(10)), Settlement{Lose, 20, -20, 0}},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %+v, want %+v", c.name, c.got, c.want)
+		}
+	}
+}
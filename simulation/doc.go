@@ -0,0 +1,6 @@
+// Package simulation is the gRPC API of the casino simulator. The messages
+// and the Simulator service are generated from simulation.proto; the
+// server behind them is the grpc command of cmd/casino.
+package simulation
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative simulation.proto
@@ -0,0 +1,1154 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        v4.25.3
+// source: simulation.proto
+
+// Package casino.simulation runs baccarat and roulette simulations for
+// other services, streaming progress while the sessions run and the
+// statistics when they are done.
+
+package simulation
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Scenario is a simulation to run. It holds the settings of a scenario
+// file; amounts are in cents and any setting left at zero keeps the
+// default of the command.
+type Scenario struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Game          string       `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`         // baccarat or roulette
+	Strategy      string       `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"` // Preset of the roulette simulator: fib, martingale or mixed
+	Variant       string       `protobuf:"bytes,3,opt,name=variant,proto3" json:"variant,omitempty"`   // european, american or la_partage for roulette
+	Table         *TableRules  `protobuf:"bytes,4,opt,name=table,proto3" json:"table,omitempty"`
+	Bets          []*Bet       `protobuf:"bytes,5,rep,name=bets,proto3" json:"bets,omitempty"` // Roulette bet layout
+	Progression   *Progression `protobuf:"bytes,6,opt,name=progression,proto3" json:"progression,omitempty"`
+	BankrollCents int64        `protobuf:"varint,7,opt,name=bankroll_cents,json=bankrollCents,proto3" json:"bankroll_cents,omitempty"`
+	StopLossCents int64        `protobuf:"varint,8,opt,name=stop_loss_cents,json=stopLossCents,proto3" json:"stop_loss_cents,omitempty"`
+	WinGoalCents  int64        `protobuf:"varint,9,opt,name=win_goal_cents,json=winGoalCents,proto3" json:"win_goal_cents,omitempty"` // Profit that ends a session
+	MaxRounds     int32        `protobuf:"varint,10,opt,name=max_rounds,json=maxRounds,proto3" json:"max_rounds,omitempty"`           // Hands or spins after which a session stops
+	Simulations   int32        `protobuf:"varint,11,opt,name=simulations,proto3" json:"simulations,omitempty"`
+	Seed          int64        `protobuf:"varint,12,opt,name=seed,proto3" json:"seed,omitempty"` // Runs with the same seed see the same cards or spins; 0 for a random seed
+}
+
+func (x *Scenario) Reset() {
+	*x = Scenario{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simulation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scenario) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scenario) ProtoMessage() {}
+
+func (x *Scenario) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scenario.ProtoReflect.Descriptor instead.
+func (*Scenario) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Scenario) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *Scenario) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Scenario) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *Scenario) GetTable() *TableRules {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *Scenario) GetBets() []*Bet {
+	if x != nil {
+		return x.Bets
+	}
+	return nil
+}
+
+func (x *Scenario) GetProgression() *Progression {
+	if x != nil {
+		return x.Progression
+	}
+	return nil
+}
+
+func (x *Scenario) GetBankrollCents() int64 {
+	if x != nil {
+		return x.BankrollCents
+	}
+	return 0
+}
+
+func (x *Scenario) GetStopLossCents() int64 {
+	if x != nil {
+		return x.StopLossCents
+	}
+	return 0
+}
+
+func (x *Scenario) GetWinGoalCents() int64 {
+	if x != nil {
+		return x.WinGoalCents
+	}
+	return 0
+}
+
+func (x *Scenario) GetMaxRounds() int32 {
+	if x != nil {
+		return x.MaxRounds
+	}
+	return 0
+}
+
+func (x *Scenario) GetSimulations() int32 {
+	if x != nil {
+		return x.Simulations
+	}
+	return 0
+}
+
+func (x *Scenario) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+// TableRules are the rules and limits of the table
+type TableRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Decks           int32   `protobuf:"varint,1,opt,name=decks,proto3" json:"decks,omitempty"`
+	Commission      string  `protobuf:"bytes,2,opt,name=commission,proto3" json:"commission,omitempty"` // exact, round or owed
+	CommissionRate  float64 `protobuf:"fixed64,3,opt,name=commission_rate,json=commissionRate,proto3" json:"commission_rate,omitempty"`
+	ChipsCents      []int64 `protobuf:"varint,4,rep,packed,name=chips_cents,json=chipsCents,proto3" json:"chips_cents,omitempty"`
+	ChipUnitCents   int64   `protobuf:"varint,15,opt,name=chip_unit_cents,json=chipUnitCents,proto3" json:"chip_unit_cents,omitempty"` // Smallest bet, or the baccarat chip that rounds payouts
+	Rounding        string  `protobuf:"bytes,5,opt,name=rounding,proto3" json:"rounding,omitempty"`                                    // up, down or refuse
+	InsideMinCents  int64   `protobuf:"varint,6,opt,name=inside_min_cents,json=insideMinCents,proto3" json:"inside_min_cents,omitempty"`
+	InsideMaxCents  int64   `protobuf:"varint,7,opt,name=inside_max_cents,json=insideMaxCents,proto3" json:"inside_max_cents,omitempty"`
+	OutsideMinCents int64   `protobuf:"varint,8,opt,name=outside_min_cents,json=outsideMinCents,proto3" json:"outside_min_cents,omitempty"`
+	OutsideMaxCents int64   `protobuf:"varint,9,opt,name=outside_max_cents,json=outsideMaxCents,proto3" json:"outside_max_cents,omitempty"`
+	TableMinCents   int64   `protobuf:"varint,10,opt,name=table_min_cents,json=tableMinCents,proto3" json:"table_min_cents,omitempty"`
+	TableMaxCents   int64   `protobuf:"varint,11,opt,name=table_max_cents,json=tableMaxCents,proto3" json:"table_max_cents,omitempty"` // Also the baccarat table limit
+	AtLimit         string  `protobuf:"bytes,12,opt,name=at_limit,json=atLimit,proto3" json:"at_limit,omitempty"`                      // cap, quit or restart
+	Bias            string  `protobuf:"bytes,13,opt,name=bias,proto3" json:"bias,omitempty"`
+	Physics         string  `protobuf:"bytes,14,opt,name=physics,proto3" json:"physics,omitempty"`
+}
+
+func (x *TableRules) Reset() {
+	*x = TableRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simulation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableRules) ProtoMessage() {}
+
+func (x *TableRules) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableRules.ProtoReflect.Descriptor instead.
+func (*TableRules) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TableRules) GetDecks() int32 {
+	if x != nil {
+		return x.Decks
+	}
+	return 0
+}
+
+func (x *TableRules) GetCommission() string {
+	if x != nil {
+		return x.Commission
+	}
+	return ""
+}
+
+func (x *TableRules) GetCommissionRate() float64 {
+	if x != nil {
+		return x.CommissionRate
+	}
+	return 0
+}
+
+func (x *TableRules) GetChipsCents() []int64 {
+	if x != nil {
+		return x.ChipsCents
+	}
+	return nil
+}
+
+func (x *TableRules) GetChipUnitCents() int64 {
+	if x != nil {
+		return x.ChipUnitCents
+	}
+	return 0
+}
+
+func (x *TableRules) GetRounding() string {
+	if x != nil {
+		return x.Rounding
+	}
+	return ""
+}
+
+func (x *TableRules) GetInsideMinCents() int64 {
+	if x != nil {
+		return x.InsideMinCents
+	}
+	return 0
+}
+
+func (x *TableRules) GetInsideMaxCents() int64 {
+	if x != nil {
+		return x.InsideMaxCents
+	}
+	return 0
+}
+
+func (x *TableRules) GetOutsideMinCents() int64 {
+	if x != nil {
+		return x.OutsideMinCents
+	}
+	return 0
+}
+
+func (x *TableRules) GetOutsideMaxCents() int64 {
+	if x != nil {
+		return x.OutsideMaxCents
+	}
+	return 0
+}
+
+func (x *TableRules) GetTableMinCents() int64 {
+	if x != nil {
+		return x.TableMinCents
+	}
+	return 0
+}
+
+func (x *TableRules) GetTableMaxCents() int64 {
+	if x != nil {
+		return x.TableMaxCents
+	}
+	return 0
+}
+
+func (x *TableRules) GetAtLimit() string {
+	if x != nil {
+		return x.AtLimit
+	}
+	return ""
+}
+
+func (x *TableRules) GetBias() string {
+	if x != nil {
+		return x.Bias
+	}
+	return ""
+}
+
+func (x *TableRules) GetPhysics() string {
+	if x != nil {
+		return x.Physics
+	}
+	return ""
+}
+
+// Bet is one bet of a roulette layout, written like "dozen 3" or "split 17-20"
+type Bet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bet         string `protobuf:"bytes,1,opt,name=bet,proto3" json:"bet,omitempty"`
+	AmountCents int64  `protobuf:"varint,2,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"` // Unit of this bet, 0 for the progression's
+}
+
+func (x *Bet) Reset() {
+	*x = Bet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simulation_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Bet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bet) ProtoMessage() {}
+
+func (x *Bet) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bet.ProtoReflect.Descriptor instead.
+func (*Bet) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Bet) GetBet() string {
+	if x != nil {
+		return x.Bet
+	}
+	return ""
+}
+
+func (x *Bet) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+// Progression is how the stakes are sized
+type Progression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	System        string  `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	UnitCents     int64   `protobuf:"varint,2,opt,name=unit_cents,json=unitCents,proto3" json:"unit_cents,omitempty"`
+	StepsCents    []int64 `protobuf:"varint,3,rep,packed,name=steps_cents,json=stepsCents,proto3" json:"steps_cents,omitempty"`
+	MaxSteps      int32   `protobuf:"varint,4,opt,name=max_steps,json=maxSteps,proto3" json:"max_steps,omitempty"`
+	ResetOnWin    *bool   `protobuf:"varint,5,opt,name=reset_on_win,json=resetOnWin,proto3,oneof" json:"reset_on_win,omitempty"`
+	AtEnd         string  `protobuf:"bytes,6,opt,name=at_end,json=atEnd,proto3" json:"at_end,omitempty"`
+	KellyFraction float64 `protobuf:"fixed64,7,opt,name=kelly_fraction,json=kellyFraction,proto3" json:"kelly_fraction,omitempty"`
+	KellyCap      float64 `protobuf:"fixed64,8,opt,name=kelly_cap,json=kellyCap,proto3" json:"kelly_cap,omitempty"`
+	KellyBet      string  `protobuf:"bytes,9,opt,name=kelly_bet,json=kellyBet,proto3" json:"kelly_bet,omitempty"`
+	Edge          float64 `protobuf:"fixed64,10,opt,name=edge,proto3" json:"edge,omitempty"`
+	Variance      float64 `protobuf:"fixed64,11,opt,name=variance,proto3" json:"variance,omitempty"`
+}
+
+func (x *Progression) Reset() {
+	*x = Progression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simulation_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Progression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progression) ProtoMessage() {}
+
+func (x *Progression) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progression.ProtoReflect.Descriptor instead.
+func (*Progression) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Progression) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *Progression) GetUnitCents() int64 {
+	if x != nil {
+		return x.UnitCents
+	}
+	return 0
+}
+
+func (x *Progression) GetStepsCents() []int64 {
+	if x != nil {
+		return x.StepsCents
+	}
+	return nil
+}
+
+func (x *Progression) GetMaxSteps() int32 {
+	if x != nil {
+		return x.MaxSteps
+	}
+	return 0
+}
+
+func (x *Progression) GetResetOnWin() bool {
+	if x != nil && x.ResetOnWin != nil {
+		return *x.ResetOnWin
+	}
+	return false
+}
+
+func (x *Progression) GetAtEnd() string {
+	if x != nil {
+		return x.AtEnd
+	}
+	return ""
+}
+
+func (x *Progression) GetKellyFraction() float64 {
+	if x != nil {
+		return x.KellyFraction
+	}
+	return 0
+}
+
+func (x *Progression) GetKellyCap() float64 {
+	if x != nil {
+		return x.KellyCap
+	}
+	return 0
+}
+
+func (x *Progression) GetKellyBet() string {
+	if x != nil {
+		return x.KellyBet
+	}
+	return ""
+}
+
+func (x *Progression) GetEdge() float64 {
+	if x != nil {
+		return x.Edge
+	}
+	return 0
+}
+
+func (x *Progression) GetVariance() float64 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+// Update is one message of a run: progress while it runs, then the statistics
+type Update struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Update:
+	//	*Update_Progress
+	//	*Update_Statistics
+	Update isUpdate_Update `protobuf_oneof:"update"`
+}
+
+func (x *Update) Reset() {
+	*x = Update{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simulation_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Update) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Update) ProtoMessage() {}
+
+func (x *Update) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Update.ProtoReflect.Descriptor instead.
+func (*Update) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{4}
+}
+
+func (m *Update) GetUpdate() isUpdate_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (x *Update) GetProgress() *Progress {
+	if x, ok := x.GetUpdate().(*Update_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *Update) GetStatistics() *Statistics {
+	if x, ok := x.GetUpdate().(*Update_Statistics); ok {
+		return x.Statistics
+	}
+	return nil
+}
+
+type isUpdate_Update interface {
+	isUpdate_Update()
+}
+
+type Update_Progress struct {
+	Progress *Progress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type Update_Statistics struct {
+	Statistics *Statistics `protobuf:"bytes,2,opt,name=statistics,proto3,oneof"`
+}
+
+func (*Update_Progress) isUpdate_Update() {}
+
+func (*Update_Statistics) isUpdate_Update() {}
+
+// Progress is how far a run has got and what it shows so far
+type Progress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions          int32   `protobuf:"varint,1,opt,name=sessions,proto3" json:"sessions,omitempty"`                          // Sessions finished
+	Total             int32   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                // Sessions in the run
+	WinRate           float64 `protobuf:"fixed64,3,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`            // Share of the finished sessions that reached the goal
+	WinRateLow        float64 `protobuf:"fixed64,4,opt,name=win_rate_low,json=winRateLow,proto3" json:"win_rate_low,omitempty"` // 95% confidence interval of the win rate
+	WinRateHigh       float64 `protobuf:"fixed64,5,opt,name=win_rate_high,json=winRateHigh,proto3" json:"win_rate_high,omitempty"`
+	SessionsPerSecond float64 `protobuf:"fixed64,6,opt,name=sessions_per_second,json=sessionsPerSecond,proto3" json:"sessions_per_second,omitempty"`
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simulation_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Progress) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *Progress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Progress) GetWinRate() float64 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *Progress) GetWinRateLow() float64 {
+	if x != nil {
+		return x.WinRateLow
+	}
+	return 0
+}
+
+func (x *Progress) GetWinRateHigh() float64 {
+	if x != nil {
+		return x.WinRateHigh
+	}
+	return 0
+}
+
+func (x *Progress) GetSessionsPerSecond() float64 {
+	if x != nil {
+		return x.SessionsPerSecond
+	}
+	return 0
+}
+
+// Statistics summarize every session of a run
+type Statistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seed           int64   `protobuf:"varint,1,opt,name=seed,proto3" json:"seed,omitempty"` // Seed the run used, to repeat it
+	Sessions       int32   `protobuf:"varint,2,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	Won            int32   `protobuf:"varint,3,opt,name=won,proto3" json:"won,omitempty"`
+	Capped         int32   `protobuf:"varint,4,opt,name=capped,proto3" json:"capped,omitempty"` // Sessions stopped at max_rounds
+	WinRate        float64 `protobuf:"fixed64,5,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`
+	WinRateLow     float64 `protobuf:"fixed64,6,opt,name=win_rate_low,json=winRateLow,proto3" json:"win_rate_low,omitempty"` // 95% confidence interval of the win rate
+	WinRateHigh    float64 `protobuf:"fixed64,7,opt,name=win_rate_high,json=winRateHigh,proto3" json:"win_rate_high,omitempty"`
+	MeanNet        float64 `protobuf:"fixed64,8,opt,name=mean_net,json=meanNet,proto3" json:"mean_net,omitempty"` // Net per session in dollars
+	NetStdDev      float64 `protobuf:"fixed64,9,opt,name=net_std_dev,json=netStdDev,proto3" json:"net_std_dev,omitempty"`
+	AverageRounds  float64 `protobuf:"fixed64,10,opt,name=average_rounds,json=averageRounds,proto3" json:"average_rounds,omitempty"`
+	MedianBalance  float64 `protobuf:"fixed64,11,opt,name=median_balance,json=medianBalance,proto3" json:"median_balance,omitempty"`
+	P5Balance      float64 `protobuf:"fixed64,12,opt,name=p5_balance,json=p5Balance,proto3" json:"p5_balance,omitempty"` // 5th percentile of the final balance
+	P95Balance     float64 `protobuf:"fixed64,13,opt,name=p95_balance,json=p95Balance,proto3" json:"p95_balance,omitempty"`
+	MedianDrawdown float64 `protobuf:"fixed64,14,opt,name=median_drawdown,json=medianDrawdown,proto3" json:"median_drawdown,omitempty"` // Largest fall from a peak, as a share of the peak
+	P95Drawdown    float64 `protobuf:"fixed64,15,opt,name=p95_drawdown,json=p95Drawdown,proto3" json:"p95_drawdown,omitempty"`
+	Ruined         int32   `protobuf:"varint,16,opt,name=ruined,proto3" json:"ruined,omitempty"` // Sessions that lost the whole bankroll
+}
+
+func (x *Statistics) Reset() {
+	*x = Statistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_simulation_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Statistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statistics) ProtoMessage() {}
+
+func (x *Statistics) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statistics.ProtoReflect.Descriptor instead.
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Statistics) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Statistics) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *Statistics) GetWon() int32 {
+	if x != nil {
+		return x.Won
+	}
+	return 0
+}
+
+func (x *Statistics) GetCapped() int32 {
+	if x != nil {
+		return x.Capped
+	}
+	return 0
+}
+
+func (x *Statistics) GetWinRate() float64 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *Statistics) GetWinRateLow() float64 {
+	if x != nil {
+		return x.WinRateLow
+	}
+	return 0
+}
+
+func (x *Statistics) GetWinRateHigh() float64 {
+	if x != nil {
+		return x.WinRateHigh
+	}
+	return 0
+}
+
+func (x *Statistics) GetMeanNet() float64 {
+	if x != nil {
+		return x.MeanNet
+	}
+	return 0
+}
+
+func (x *Statistics) GetNetStdDev() float64 {
+	if x != nil {
+		return x.NetStdDev
+	}
+	return 0
+}
+
+func (x *Statistics) GetAverageRounds() float64 {
+	if x != nil {
+		return x.AverageRounds
+	}
+	return 0
+}
+
+func (x *Statistics) GetMedianBalance() float64 {
+	if x != nil {
+		return x.MedianBalance
+	}
+	return 0
+}
+
+func (x *Statistics) GetP5Balance() float64 {
+	if x != nil {
+		return x.P5Balance
+	}
+	return 0
+}
+
+func (x *Statistics) GetP95Balance() float64 {
+	if x != nil {
+		return x.P95Balance
+	}
+	return 0
+}
+
+func (x *Statistics) GetMedianDrawdown() float64 {
+	if x != nil {
+		return x.MedianDrawdown
+	}
+	return 0
+}
+
+func (x *Statistics) GetP95Drawdown() float64 {
+	if x != nil {
+		return x.P95Drawdown
+	}
+	return 0
+}
+
+func (x *Statistics) GetRuined() int32 {
+	if x != nil {
+		return x.Ruined
+	}
+	return 0
+}
+
+var File_simulation_proto protoreflect.FileDescriptor
+
+var file_simulation_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x11, 0x63, 0x61, 0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xc1, 0x03, 0x0a, 0x08, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72,
+	0x69, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x05,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x61,
+	0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x12, 0x2a, 0x0a, 0x04, 0x62, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x63, 0x61, 0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x42, 0x65, 0x74, 0x52, 0x04, 0x62, 0x65, 0x74, 0x73, 0x12, 0x40, 0x0a,
+	0x0b, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x61, 0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x25, 0x0a, 0x0e, 0x62, 0x61, 0x6e, 0x6b, 0x72, 0x6f, 0x6c, 0x6c, 0x5f, 0x63, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x61, 0x6e, 0x6b, 0x72, 0x6f, 0x6c,
+	0x6c, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c,
+	0x6f, 0x73, 0x73, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x73, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x73, 0x73, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24,
+	0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x5f, 0x67, 0x6f, 0x61, 0x6c, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x47, 0x6f, 0x61, 0x6c, 0x43,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x22, 0x95, 0x04, 0x0a, 0x0a, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x63, 0x6b,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x63, 0x6b, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x69, 0x70, 0x73,
+	0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x68,
+	0x69, 0x70, 0x73, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x68, 0x69, 0x70,
+	0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x63, 0x68, 0x69, 0x70, 0x55, 0x6e, 0x69, 0x74, 0x43, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x28, 0x0a, 0x10,
+	0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x4d, 0x69,
+	0x6e, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65,
+	0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x4d, 0x61, 0x78, 0x43, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x5f,
+	0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6f, 0x75, 0x74,
+	0x73, 0x69, 0x64, 0x65, 0x4d, 0x69, 0x6e, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65,
+	0x4d, 0x61, 0x78, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x69, 0x6e, 0x43, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x26, 0x0a, 0x0f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x4d, 0x61, 0x78, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x74, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x74, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x61, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x62, 0x69, 0x61, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x68, 0x79, 0x73, 0x69,
+	0x63, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x68, 0x79, 0x73, 0x69, 0x63,
+	0x73, 0x22, 0x3a, 0x0a, 0x03, 0x42, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xe2, 0x02,
+	0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x63, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x6e, 0x69, 0x74, 0x43,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x65, 0x70, 0x73, 0x5f, 0x63, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x65, 0x70, 0x73,
+	0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x65,
+	0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x65,
+	0x70, 0x73, 0x12, 0x25, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6f, 0x6e, 0x5f, 0x77,
+	0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x65,
+	0x74, 0x4f, 0x6e, 0x57, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x74, 0x5f,
+	0x65, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x74, 0x45, 0x6e, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x6b, 0x65, 0x6c, 0x6c, 0x79, 0x5f, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6b, 0x65, 0x6c, 0x6c, 0x79, 0x46,
+	0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x6c, 0x6c, 0x79,
+	0x5f, 0x63, 0x61, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6b, 0x65, 0x6c, 0x6c,
+	0x79, 0x43, 0x61, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x6c, 0x6c, 0x79, 0x5f, 0x62, 0x65,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6b, 0x65, 0x6c, 0x6c, 0x79, 0x42, 0x65,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x04, 0x65, 0x64, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63,
+	0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6f, 0x6e, 0x5f, 0x77,
+	0x69, 0x6e, 0x22, 0x8e, 0x01, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x63, 0x61, 0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3f, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63,
+	0x61, 0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x73,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x22, 0xcd, 0x01, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a,
+	0x0c, 0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x77, 0x12,
+	0x22, 0x0a, 0x0d, 0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x69, 0x67, 0x68,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x48,
+	0x69, 0x67, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f,
+	0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x11, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x22, 0xf4, 0x03, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x77, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x70, 0x70, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x61, 0x70, 0x70, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x5f, 0x72,
+	0x61, 0x74, 0x65, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x77,
+	0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x77, 0x12, 0x22, 0x0a, 0x0d, 0x77, 0x69, 0x6e,
+	0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x48, 0x69, 0x67, 0x68, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x65, 0x61, 0x6e, 0x5f, 0x6e, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x6d, 0x65, 0x61, 0x6e, 0x4e, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0b, 0x6e, 0x65, 0x74, 0x5f,
+	0x73, 0x74, 0x64, 0x5f, 0x64, 0x65, 0x76, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6e,
+	0x65, 0x74, 0x53, 0x74, 0x64, 0x44, 0x65, 0x76, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0d, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x35, 0x5f, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x35, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x39, 0x35, 0x5f, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x39, 0x35, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e,
+	0x5f, 0x64, 0x72, 0x61, 0x77, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x44, 0x72, 0x61, 0x77, 0x64, 0x6f, 0x77, 0x6e, 0x12,
+	0x21, 0x0a, 0x0c, 0x70, 0x39, 0x35, 0x5f, 0x64, 0x72, 0x61, 0x77, 0x64, 0x6f, 0x77, 0x6e, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x39, 0x35, 0x44, 0x72, 0x61, 0x77, 0x64, 0x6f,
+	0x77, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x75, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x72, 0x75, 0x69, 0x6e, 0x65, 0x64, 0x32, 0x4c, 0x0a, 0x09, 0x53, 0x69,
+	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3f, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12, 0x1b,
+	0x2e, 0x63, 0x61, 0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x1a, 0x19, 0x2e, 0x63, 0x61,
+	0x73, 0x69, 0x6e, 0x6f, 0x2e, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x42, 0x72, 0x79, 0x63, 0x65, 0x57, 0x61, 0x79, 0x6e,
+	0x65, 0x2f, 0x63, 0x61, 0x73, 0x69, 0x6e, 0x6f, 0x2f, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_simulation_proto_rawDescOnce sync.Once
+	file_simulation_proto_rawDescData = file_simulation_proto_rawDesc
+)
+
+func file_simulation_proto_rawDescGZIP() []byte {
+	file_simulation_proto_rawDescOnce.Do(func() {
+		file_simulation_proto_rawDescData = protoimpl.X.CompressGZIP(file_simulation_proto_rawDescData)
+	})
+	return file_simulation_proto_rawDescData
+}
+
+var file_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_simulation_proto_goTypes = []interface{}{
+	(*Scenario)(nil),    // 0: casino.simulation.Scenario
+	(*TableRules)(nil),  // 1: casino.simulation.TableRules
+	(*Bet)(nil),         // 2: casino.simulation.Bet
+	(*Progression)(nil), // 3: casino.simulation.Progression
+	(*Update)(nil),      // 4: casino.simulation.Update
+	(*Progress)(nil),    // 5: casino.simulation.Progress
+	(*Statistics)(nil),  // 6: casino.simulation.Statistics
+}
+var file_simulation_proto_depIdxs = []int32{
+	1, // 0: casino.simulation.Scenario.table:type_name -> casino.simulation.TableRules
+	2, // 1: casino.simulation.Scenario.bets:type_name -> casino.simulation.Bet
+	3, // 2: casino.simulation.Scenario.progression:type_name -> casino.simulation.Progression
+	5, // 3: casino.simulation.Update.progress:type_name -> casino.simulation.Progress
+	6, // 4: casino.simulation.Update.statistics:type_name -> casino.simulation.Statistics
+	0, // 5: casino.simulation.Simulator.Run:input_type -> casino.simulation.Scenario
+	4, // 6: casino.simulation.Simulator.Run:output_type -> casino.simulation.Update
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_simulation_proto_init() }
+func file_simulation_proto_init() {
+	if File_simulation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_simulation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Scenario); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simulation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simulation_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Bet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simulation_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Progression); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simulation_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Update); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simulation_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Progress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_simulation_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Statistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_simulation_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_simulation_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*Update_Progress)(nil),
+		(*Update_Statistics)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_simulation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_simulation_proto_goTypes,
+		DependencyIndexes: file_simulation_proto_depIdxs,
+		MessageInfos:      file_simulation_proto_msgTypes,
+	}.Build()
+	File_simulation_proto = out.File
+	file_simulation_proto_rawDesc = nil
+	file_simulation_proto_goTypes = nil
+	file_simulation_proto_depIdxs = nil
+}
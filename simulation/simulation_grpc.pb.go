@@ -0,0 +1,144 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: simulation.proto
+
+// Package casino.simulation runs baccarat and roulette simulations for
+// other services, streaming progress while the sessions run and the
+// statistics when they are done.
+
+package simulation
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Simulator_Run_FullMethodName = "/casino.simulation.Simulator/Run"
+)
+
+// SimulatorClient is the client API for Simulator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SimulatorClient interface {
+	// Run simulates a scenario, sending progress as sessions finish and the
+	// statistics of every session last
+	Run(ctx context.Context, in *Scenario, opts ...grpc.CallOption) (Simulator_RunClient, error)
+}
+
+type simulatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulatorClient(cc grpc.ClientConnInterface) SimulatorClient {
+	return &simulatorClient{cc}
+}
+
+func (c *simulatorClient) Run(ctx context.Context, in *Scenario, opts ...grpc.CallOption) (Simulator_RunClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Simulator_ServiceDesc.Streams[0], Simulator_Run_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &simulatorRunClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Simulator_RunClient interface {
+	Recv() (*Update, error)
+	grpc.ClientStream
+}
+
+type simulatorRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *simulatorRunClient) Recv() (*Update, error) {
+	m := new(Update)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SimulatorServer is the server API for Simulator service.
+// All implementations must embed UnimplementedSimulatorServer
+// for forward compatibility
+type SimulatorServer interface {
+	// Run simulates a scenario, sending progress as sessions finish and the
+	// statistics of every session last
+	Run(*Scenario, Simulator_RunServer) error
+	mustEmbedUnimplementedSimulatorServer()
+}
+
+// UnimplementedSimulatorServer must be embedded to have forward compatible implementations.
+type UnimplementedSimulatorServer struct {
+}
+
+func (UnimplementedSimulatorServer) Run(*Scenario, Simulator_RunServer) error {
+	return status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+func (UnimplementedSimulatorServer) mustEmbedUnimplementedSimulatorServer() {}
+
+// UnsafeSimulatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulatorServer will
+// result in compilation errors.
+type UnsafeSimulatorServer interface {
+	mustEmbedUnimplementedSimulatorServer()
+}
+
+func RegisterSimulatorServer(s grpc.ServiceRegistrar, srv SimulatorServer) {
+	s.RegisterService(&Simulator_ServiceDesc, srv)
+}
+
+func _Simulator_Run_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Scenario)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulatorServer).Run(m, &simulatorRunServer{stream})
+}
+
+type Simulator_RunServer interface {
+	Send(*Update) error
+	grpc.ServerStream
+}
+
+type simulatorRunServer struct {
+	grpc.ServerStream
+}
+
+func (x *simulatorRunServer) Send(m *Update) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Simulator_ServiceDesc is the grpc.ServiceDesc for Simulator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Simulator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "casino.simulation.Simulator",
+	HandlerType: (*SimulatorServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Run",
+			Handler:       _Simulator_Run_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "simulation.proto",
+}
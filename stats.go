@@ -0,0 +1,151 @@
+package casino
+
+import (
+	"math"
+	"sort"
+)
+
+// Drawdown tracks the largest fall of a balance from its running peak
+type Drawdown struct {
+	Peak Money
+	Max  float64 // Largest fall as a share of the peak
+}
+
+// Record updates the drawdown with the latest balance
+func (d *Drawdown) Record(balance Money) {
+	if balance > d.Peak {
+		d.Peak = balance
+	}
+	if d.Peak > 0 {
+		if fall := float64(d.Peak-balance) / float64(d.Peak); fall > d.Max {
+			d.Max = fall
+		}
+	}
+}
+
+// LogGrowth returns the log growth of a balance per bet, or negative
+// infinity when it was wiped out
+func LogGrowth(initial, final Money, bets int) float64 {
+	if final <= 0 {
+		return math.Inf(-1)
+	}
+	if bets == 0 || initial <= 0 {
+		return 0
+	}
+	return math.Log(float64(final)/float64(initial)) / float64(bets)
+}
+
+// Percentile returns the p-th percentile (0 to 100) of the values
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	low := int(math.Floor(rank))
+	high := int(math.Ceil(rank))
+	return sorted[low] + (sorted[high]-sorted[low])*(rank-float64(low))
+}
+
+// Comparison is the paired difference between two strategies played on the
+// same spins or shoes: its mean, confidence interval and two-sided p-value
+type Comparison struct {
+	Mean  float64
+	Low   float64
+	High  float64
+	P     float64
+	Pairs int
+}
+
+// Significant reports whether the difference is significant at the level
+func (c Comparison) Significant(level float64) bool {
+	return c.P < level
+}
+
+// normalQuantile returns the z score a two-sided interval of the confidence reaches
+func normalQuantile(confidence float64) float64 {
+	return math.Sqrt2 * math.Erfinv(confidence)
+}
+
+// normalPValue returns the two-sided p-value of a z score
+func normalPValue(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// WilsonInterval returns the Wilson score interval of a success rate,
+// which stays inside 0 to 1 and behaves with few trials or rare successes
+func WilsonInterval(successes, trials int, confidence float64) (float64, float64) {
+	if trials == 0 {
+		return 0, 1
+	}
+	z := normalQuantile(confidence)
+	n := float64(trials)
+	p := float64(successes) / n
+	center := (p + z*z/(2*n)) / (1 + z*z/n)
+	spread := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+	return math.Max(0, center-spread), math.Min(1, center+spread)
+}
+
+// PairedDifference compares paired outcomes such as the net of each
+// session with a paired t-test on the differences a-b, using the normal
+// approximation that holds for the thousands of sessions a simulation plays
+func PairedDifference(a, b []float64, confidence float64) Comparison {
+	n := len(a)
+	if n == 0 || n != len(b) {
+		return Comparison{P: 1}
+	}
+	mean := 0.0
+	for i := range a {
+		mean += a[i] - b[i]
+	}
+	mean /= float64(n)
+	variance := 0.0
+	for i := range a {
+		d := a[i] - b[i] - mean
+		variance += d * d
+	}
+	if n > 1 {
+		variance /= float64(n - 1)
+	}
+	se := math.Sqrt(variance / float64(n))
+	c := Comparison{Mean: mean, Low: mean, High: mean, P: 1, Pairs: n}
+	if se > 0 {
+		z := normalQuantile(confidence)
+		c.Low, c.High = mean-z*se, mean+z*se
+		c.P = normalPValue(mean / se)
+	} else if mean != 0 {
+		c.P = 0
+	}
+	return c
+}
+
+// McNemar compares paired successes such as reaching the profit goal. Only
+// the sessions one strategy won and the other lost tell them apart; the
+// interval is the Wald interval of the paired difference in success rates.
+func McNemar(a, b []bool, confidence float64) Comparison {
+	n := len(a)
+	if n == 0 || n != len(b) {
+		return Comparison{P: 1}
+	}
+	onlyA, onlyB := 0, 0
+	for i := range a {
+		switch {
+		case a[i] && !b[i]:
+			onlyA++
+		case b[i] && !a[i]:
+			onlyB++
+		}
+	}
+	mean := float64(onlyA-onlyB) / float64(n)
+	c := Comparison{Mean: mean, Low: mean, High: mean, P: 1, Pairs: n}
+	discordant := float64(onlyA + onlyB)
+	if discordant == 0 {
+		return c
+	}
+	se := math.Sqrt(discordant/float64(n)-mean*mean) / math.Sqrt(float64(n))
+	z := normalQuantile(confidence)
+	c.Low, c.High = mean-z*se, mean+z*se
+	c.P = normalPValue(float64(onlyA-onlyB) / math.Sqrt(discordant))
+	return c
+}
@@ -0,0 +1,56 @@
+package casino
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPairedDifference(t *testing.T) {
+	// b is a plus noise the pairing cancels, so a small shift stands out
+	a := make([]float64, 1000)
+	b := make([]float64, 1000)
+	for i := range a {
+		a[i] = float64(i%37) * 100
+		b[i] = a[i] - 1 - float64(i%2)
+	}
+	c := PairedDifference(a, b, 0.95)
+	if math.Abs(c.Mean-1.5) > 1e-9 || c.Low > 1.5 || c.High < 1.5 || !c.Significant(0.05) {
+		t.Errorf("PairedDifference = %+v, want a significant mean of 1.5", c)
+	}
+	if c := PairedDifference(a, a, 0.95); c.Mean != 0 || c.P != 1 {
+		t.Errorf("PairedDifference of equal samples = %+v", c)
+	}
+}
+
+func TestMcNemar(t *testing.T) {
+	// 30 sessions only a won and 10 only b won, out of 200
+	a := make([]bool, 200)
+	b := make([]bool, 200)
+	for i := 0; i < 30; i++ {
+		a[i] = true
+	}
+	for i := 30; i < 40; i++ {
+		b[i] = true
+	}
+	for i := 40; i < 100; i++ {
+		a[i], b[i] = true, true
+	}
+	c := McNemar(a, b, 0.95)
+	if math.Abs(c.Mean-0.1) > 1e-9 {
+		t.Errorf("McNemar difference = %v, want 0.1", c.Mean)
+	}
+	// z = 20 / sqrt(40)
+	if want := math.Erfc(20 / math.Sqrt(40) / math.Sqrt2); math.Abs(c.P-want) > 1e-12 || !c.Significant(0.01) {
+		t.Errorf("McNemar p = %v, want %v", c.P, want)
+	}
+}
+
+func TestWilsonInterval(t *testing.T) {
+	low, high := WilsonInterval(50, 100, 0.95)
+	if math.Abs(low-0.4038) > 1e-3 || math.Abs(high-0.5962) > 1e-3 {
+		t.Errorf("WilsonInterval(50, 100) = [%.4f, %.4f], want [0.4038, 0.5962]", low, high)
+	}
+	if low, high := WilsonInterval(0, 20, 0.95); low != 0 || high <= 0 || high >= 0.2 {
+		t.Errorf("WilsonInterval(0, 20) = [%.4f, %.4f]", low, high)
+	}
+}